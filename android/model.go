@@ -17,12 +17,12 @@
 package android
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
-	"sort"
 	"strings"
 )
 
@@ -39,35 +39,61 @@ var specials = []special{
 	{'"', `\"`},
 }
 
-// Resources is the root element of android resources in general
+// ToolsNamespace is the xml namespace of the android tools attributes
+const ToolsNamespace = "http://schemas.android.com/tools"
+
+// Resources is the root element of android resources in general. File is the name of the parsed file, if known.
 type Resources struct {
 	XMLName      xml.Name      `xml:"resources"`
+	File         string        `xml:"-"`
 	Strings      []String      `xml:"string"`
 	StringArrays []StringArray `xml:"string-array"`
 	Plurals      []Plurals     `xml:"plurals"`
 }
 
-// String is the simple text element
+// FormatICU marks a string, whose text uses the ICU MessageFormat syntax instead of the java Formatter syntax, like
+// <string name="x_has_cats" tools:format="icu">{count, plural, one {# cat} other {# cats}}</string>.
+const FormatICU = "icu"
+
+// String is the simple text element. The tools:format attribute is an extension, which is removed by the android
+// build tools, see also FormatICU.
 type String struct {
 	XMLName      xml.Name `xml:"string"`
 	Name         string   `xml:"name,attr"`
 	Translatable *bool    `xml:"translatable,attr"`
+	Format       string   `xml:"format,attr,omitempty"`
 	Text         string   `xml:",chardata"`
+	Pos          Position `xml:"-"`
 }
 
-// StringArray cannot contain placeholders or plurals
+// StringArray cannot contain placeholders or plurals. The tools:list attribute is an extension, which is removed
+// by the android build tools. It declares that the items are used as a sentence, which is joined by the list
+// patterns of conjunction, disjunction or unit, like
+// <string-array name="toppings" tools:list="conjunction">.
+//
+// The tools:select attribute is another extension, which turns the array into a select, whose comma separated
+// selectors name the case of each item in order, like
+// <string-array name="x_invited_you" tools:select="female,male,other">. In contrast to a plain array, the items
+// may contain placeholders.
 type StringArray struct {
 	XMLName      xml.Name `xml:"string-array"`
 	Name         string   `xml:"name,attr"`
 	Translatable *bool    `xml:"translatable,attr"`
+	List         string   `xml:"list,attr,omitempty"`
+	Select       string   `xml:"select,attr,omitempty"`
 	Items        []string `xml:"item"`
+	Pos          Position `xml:"-"`
 }
 
-// Plurals contains the CLDR classified translations for one, other, many etc
+// Plurals contains the CLDR classified translations for one, other, many etc. Android itself only knows cardinal
+// plurals, so ordinals like 1st or 2nd are marked in the tools namespace with tools:ordinal="true", which is
+// removed by the android build tools.
 type Plurals struct {
 	XMLName xml.Name     `xml:"plurals"`
 	Name    string       `xml:"name,attr"`
+	Ordinal bool         `xml:"ordinal,attr,omitempty"`
 	Items   []PluralItem `xml:"item"`
+	Pos     Position     `xml:"-"`
 }
 
 // PluralItem is the grammatically quantified message
@@ -77,7 +103,14 @@ type PluralItem struct {
 	Text     string   `xml:",chardata"`
 }
 
-// Read parses an android strings.xml document
+// Position is the 1 based line and byte column of the start tag of an element. The zero Position is unknown.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Read parses an android strings.xml document and records the position of each string, string-array and plurals.
+// If the reader has a name, like an *os.File, it becomes the File of the resources.
 func Read(reader io.Reader) (Resources, error) {
 	res := Resources{}
 	tmp, err := ioutil.ReadAll(reader)
@@ -91,9 +124,84 @@ func Read(reader io.Reader) (Resources, error) {
 		return res, fmt.Errorf("failed to parse xml: %w", err)
 	}
 
+	if named, ok := reader.(interface{ Name() string }); ok {
+		res.File = named.Name()
+	}
+
+	positions, err := positionsOf(tmp)
+	if err != nil {
+		return res, fmt.Errorf("failed to parse xml: %w", err)
+	}
+
+	for i := range res.Strings {
+		res.Strings[i].Pos = positions.next("string")
+	}
+
+	for i := range res.StringArrays {
+		res.StringArrays[i].Pos = positions.next("string-array")
+	}
+
+	for i := range res.Plurals {
+		res.Plurals[i].Pos = positions.next("plurals")
+	}
+
 	return res, nil
 }
 
+// positions are the start tag positions of the child elements of the root, in document order by element name
+type positions map[string][]Position
+
+// next consumes the position of the next element with the given name
+func (p positions) next(name string) Position {
+	if len(p[name]) == 0 {
+		return Position{}
+	}
+
+	pos := p[name][0]
+	p[name] = p[name][1:]
+
+	return pos
+}
+
+// positionsOf scans the document, so that the positions are in the same order as the elements decoded by
+// xml.Unmarshal
+func positionsOf(src []byte) (positions, error) {
+	dec := xml.NewDecoder(bytes.NewReader(src))
+	res := make(positions)
+	depth := 0
+
+	for {
+		offset := int(dec.InputOffset())
+
+		token, err := dec.Token()
+		if err == io.EOF {
+			return res, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 1 {
+				res[t.Name.Local] = append(res[t.Name.Local], positionOf(src, offset))
+			}
+
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// positionOf converts the byte offset into a line and column
+func positionOf(src []byte, offset int) Position {
+	lineStart := bytes.LastIndexByte(src[:offset], '\n') + 1
+
+	return Position{Line: bytes.Count(src[:offset], []byte{'\n'}) + 1, Column: offset - lineStart + 1}
+}
+
 // ReadFile parses an android strings.xml file from the file system
 func ReadFile(fname string) (Resources, error) {
 	file, err := os.Open(fname)
@@ -108,8 +216,14 @@ func ReadFile(fname string) (Resources, error) {
 	return Read(file)
 }
 
-// Decodes unescapes the android string and also replaces the indexed arguments with the notation understood by go
-func Decode(androidStr string) string {
+// Decodes unescapes the android string and also replaces the indexed arguments with the notation understood by go.
+// See AsGoFormat for the directives, which cannot be decoded.
+func Decode(androidStr string) (string, error) {
+	return AsGoFormat(Unescape(androidStr))
+}
+
+// Unescape removes the surrounding quotes and the escapes of special chars but keeps any format directive as is.
+func Unescape(androidStr string) string {
 	//nolint: gomnd // cannot be escaped with 1 or less chars
 	if len(androidStr) <= 1 {
 		return androidStr
@@ -126,20 +240,5 @@ func Decode(androidStr string) string {
 		androidStr = strings.ReplaceAll(androidStr, special.escaped, string(special.c))
 	}
 
-	// detect %1$s and %2$d types of indices
-	// there are a lot of them https://developer.android.com/reference/java/util/Formatter
-	sb := &strings.Builder{}
-	pos := 0
-	elems := ParsePrintf(androidStr)
-	sort.Sort(pfsSortByPosIndex(elems))
-	for _, elem := range elems {
-		sb.WriteString(androidStr[pos:elem.Pos])
-		sb.WriteString(elem.AsGoFormatSpecifier())
-		pos = elem.End
-	}
-	sb.WriteString(androidStr[pos:])
-
-	androidStr = sb.String()
-
 	return androidStr
 }
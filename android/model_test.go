@@ -16,6 +16,7 @@ package android
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -41,9 +42,59 @@ func TestDecode(t *testing.T) {
 	// nolint: scopelint // tt is a value, so this is a false-positive
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := Decode(tt.args); got != tt.want {
+			got, err := Decode(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
 				t.Errorf("Decode() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestReadPositions(t *testing.T) {
+	res, err := Read(strings.NewReader(`<resources>
+    <string name="a">a</string><string name="b">b</string>
+    <plurals name="c">
+        <item quantity="other">c</item>
+    </plurals>
+	<string-array name="d"><item>d</item></string-array>
+</resources>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.File != "" {
+		t.Fatalf("expected no file but got %s", res.File)
+	}
+
+	tests := []struct {
+		name string
+		got  Position
+		want Position
+	}{
+		{"string", res.Strings[0].Pos, Position{Line: 2, Column: 5}},
+		{"second string on the same line", res.Strings[1].Pos, Position{Line: 2, Column: 32}},
+		{"plurals", res.Plurals[0].Pos, Position{Line: 3, Column: 5}},
+		{"string-array after tab", res.StringArrays[0].Pos, Position{Line: 6, Column: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("expected %+v but got %+v", tt.want, tt.got)
+			}
+		})
+	}
+
+	res, err = ReadFile("strings_test.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.File != "strings_test.xml" || res.Strings[0].Pos.Line == 0 {
+		t.Fatalf("expected the file and position but got %s %+v", res.File, res.Strings[0].Pos)
+	}
+}
@@ -15,14 +15,34 @@
 package android
 
 import (
-	"regexp"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
 )
 
-// matcher is a monster regex to find android supported printf declarations, in contrast to go indices are %<num>$<verb>
-var formatMatcher = regexp.MustCompile(`(?:\x25\x25)|(\x25(?:(?:[1-9]\d*)\$|\((?:[^\)]+)\))?(?:\+)?(?:0|'[^$])?(?:-)?(?:\d+)?(?:\.(?:\d+))?(?:[b-fiosuxX]))`)
+// javaFlags are the flags of the java Formatter. The < is not a flag, but the relative index.
+const javaFlags = "-#+ 0,("
+
+// javaConversions are the valid conversions of the java Formatter, besides t and T
+const javaConversions = "bBhHsScCdoxXeEfgGaA%n"
+
+// javaDateTimeSuffixes are the valid suffixes of the t and T conversions
+const javaDateTimeSuffixes = "HIklMSLNpzZsQBbhAaCYyjmdeRTrDFc"
+
+// goConversions are the java conversions, which the go fmt package formats in the same way
+const goConversions = "bcdoxXeEfgGs"
+
+// ErrUnsupportedDirective indicates a java directive, which cannot be formatted by the go fmt package, like the
+// grouping flag in %,d, the negative parentheses in %(d, the date and time conversions like %tY or the upper
+// case conversions like %S.
+type ErrUnsupportedDirective struct {
+	Directive string
+}
+
+func (e ErrUnsupportedDirective) Error() string {
+	return fmt.Sprintf("the directive '%s' has no go equivalent", e.Directive)
+}
 
 // PrintFormatSpecifier represents a part of a printf string, like %s, %[5]d or %10.10s.
 type PrintfFormatSpecifier struct {
@@ -31,6 +51,13 @@ type PrintfFormatSpecifier struct {
 	End      int    // End is the end index in src where this specifier is located
 	Index    int    // Index is the argument position. Either this is the natural order or derived by the indexed position.
 	PosIndex int    // PosIndex is the positional index, which is the order as parsed
+
+	Arg       int    // Arg is the zero based index of the formatted argument, as the java Formatter consumes it
+	Flags     string // Flags contains the declared flags out of "-#+ 0,(" in their order
+	Width     int    // Width is the declared width or -1
+	Precision int    // Precision is the declared precision or -1
+	Relative  bool   // Relative is true for the < index, which formats the argument of the previous specifier
+	DateTime  byte   // DateTime is the suffix of a t or T conversion, like Y for %tY, or 0
 }
 
 // String returns the entire format specifier
@@ -38,73 +65,323 @@ func (f *PrintfFormatSpecifier) String() string {
 	return f.Src[f.Pos:f.End]
 }
 
-// Verb returns the single character and not the entire formatting directive.
+// Verb returns the single character conversion and not the entire formatting directive, so t for %tY.
 func (f *PrintfFormatSpecifier) Verb() byte {
+	if f.DateTime != 0 {
+		return f.Src[f.End-2]
+	}
+
 	return f.Src[f.End-1]
 }
 
 // Indexed returns true, if a %<num>$<verb> structure is declared
 func (f *PrintfFormatSpecifier) Indexed() bool {
-	return strings.Index(f.String(), "$") > 0
+	return strings.IndexByte(f.String(), '$') > 0
 }
 
 // AsGoFormatSpecifier converts the $ index into a [] index
 func (f *PrintfFormatSpecifier) AsGoFormatSpecifier() string {
-	if f.Indexed() {
-		str := f.String()
-		b := strings.Index(str, "%")
-		e := strings.Index(str, "$")
-		return "%[" + str[b+1:e] + "]" + str[e+1:]
+	if f.Indexed() || f.Relative {
+		return f.goDirective(true)
 	}
+
 	return f.String()
 }
 
-// index parses tries to parse the from String(). Returns -1 if not
-func (f *PrintfFormatSpecifier) index() int {
-	str := f.String()
-	b := strings.Index(str, "%")
-	e := strings.Index(str, "$")
-	if e > b {
-		i, err := strconv.ParseInt(str[b+1:e], 10, 32)
-		if err != nil {
-			return -1
+// goDirective returns the directive in go notation, optionally with an explicit argument index. Go expects the
+// index in front of the verb, because %[1]5d is invalid but %5[1]d is not.
+func (f *PrintfFormatSpecifier) goDirective(indexed bool) string {
+	sb := &strings.Builder{}
+	sb.WriteByte('%')
+	sb.WriteString(f.Flags)
+
+	if f.Width >= 0 {
+		sb.WriteString(strconv.Itoa(f.Width))
+	}
+
+	if f.Precision >= 0 {
+		sb.WriteString("." + strconv.Itoa(f.Precision))
+	}
+
+	if indexed {
+		sb.WriteString("[" + strconv.Itoa(f.Arg+1) + "]")
+	}
+
+	if f.DateTime != 0 {
+		sb.WriteString(f.Src[f.End-2 : f.End])
+	} else {
+		sb.WriteString(f.Src[f.End-1 : f.End])
+	}
+
+	return sb.String()
+}
+
+// AsGoFormat replaces the java indices like %1$s and %2$d with the go notation %[1]s and %[2]d. Everything else
+// is kept as is. In java, the ordinary index is independent of the explicit ones, but go continues after the last
+// index, so an ordinary specifier gets an explicit go index, if both differ. A %n becomes a line break. A directive,
+// which go cannot format, returns an ErrUnsupportedDirective.
+func AsGoFormat(javaStr string) (string, error) {
+	// there are a lot of them https://developer.android.com/reference/java/util/Formatter
+	sb := &strings.Builder{}
+	pos := 0
+	elems := ParsePrintf(javaStr)
+	sort.Sort(pfsSortByPosIndex(elems))
+
+	// goArg is the argument, which go would format next
+	goArg := 0
+
+	for _, elem := range elems {
+		if strings.ContainsAny(elem.Flags, ",(") || strings.IndexByte(goConversions, elem.Verb()) < 0 {
+			return "", ErrUnsupportedDirective{Directive: elem.String()}
+		}
+
+		writeLineBreaks(sb, javaStr[pos:elem.Pos])
+
+		if elem.Indexed() || elem.Relative || elem.Arg != goArg {
+			sb.WriteString(elem.goDirective(true))
+		} else {
+			sb.WriteString(elem.String())
+		}
+
+		goArg = elem.Arg + 1
+		pos = elem.End
+	}
+
+	writeLineBreaks(sb, javaStr[pos:])
+
+	return sb.String(), nil
+}
+
+// writeLineBreaks writes the text without specifiers and replaces %n by a line break
+func writeLineBreaks(sb *strings.Builder, text string) {
+	for i := 0; i < len(text); i++ {
+		if text[i] == '%' && i+1 < len(text) {
+			switch text[i+1] {
+			case 'n':
+				sb.WriteByte('\n')
+				i++
+
+				continue
+			case '%':
+				sb.WriteString("%%")
+				i++
+
+				continue
+			}
+		}
+
+		sb.WriteByte(text[i])
+	}
+}
+
+// AsJavaFormat is the inverse of AsGoFormat and replaces go indices like %[1]s with the java notation %1$s.
+func AsJavaFormat(goStr string) string {
+	sb := &strings.Builder{}
+	for i := 0; i < len(goStr); i++ {
+		if goStr[i] == '%' {
+			i = encodeDirective(sb, goStr, i)
+			continue
 		}
-		return int(i)
+
+		sb.WriteByte(goStr[i])
+	}
+
+	return sb.String()
+}
+
+// encodeDirective writes the directive starting at pos and returns the index of its last consumed byte.
+// Go allows the argument index in front of the flags or the verb, like %[1]s, %-[1]s or %-5.2[1]f, but java
+// expects the index first, like %1$-5.2f.
+func encodeDirective(sb *strings.Builder, str string, pos int) int {
+	if pos+1 < len(str) && str[pos+1] == '%' {
+		sb.WriteString("%%")
+		return pos + 1
+	}
+
+	i := pos + 1
+	for i < len(str) && strings.IndexByte("+-# 0", str[i]) >= 0 {
+		i++
+	}
+
+	flags := str[pos+1 : i]
+
+	num, i := goIndex(str, i)
+
+	widthStart := i
+	for i < len(str) && ('0' <= str[i] && str[i] <= '9' || str[i] == '.') {
+		i++
+	}
+
+	width := str[widthStart:i]
+
+	if num == "" {
+		num, i = goIndex(str, i)
 	}
-	return -1
+
+	if num == "" || i >= len(str) {
+		sb.WriteByte('%')
+		return pos
+	}
+
+	sb.WriteString("%" + num + "$" + flags + width)
+
+	return i - 1
+}
+
+// goIndex returns the number of a go argument index like [2] at i and the index behind it
+func goIndex(str string, i int) (string, int) {
+	if i >= len(str) || str[i] != '[' {
+		return "", i
+	}
+
+	end := strings.IndexByte(str[i:], ']')
+	if end < 0 {
+		return "", i
+	}
+
+	num := str[i+1 : i+end]
+	if _, err := strconv.Atoi(num); err != nil {
+		return "", i
+	}
+
+	return num, i + end + 1
 }
 
-// ParsePrintf returns all found format specifiers and returns them in a sorted order by index position
+// ParsePrintf returns all found format specifiers and returns them in a sorted order by index position. The
+// scanner follows the grammar of the java Formatter %[argument_index$][flags][width][.precision]conversion,
+// including the relative index %<s and the date and time conversions like %tY. Invalid directives, %% and %n are
+// not specifiers. Index enumerates the specifiers in the order of the arguments, which they consume.
 func ParsePrintf(str string) []PrintfFormatSpecifier {
 	var specs []PrintfFormatSpecifier
-	indices := formatMatcher.FindAllStringIndex(str, -1)
-	idx := 0
-	for _, pos := range indices {
-		spec := PrintfFormatSpecifier{
-			Src:      str,
-			Pos:      pos[0],
-			End:      pos[1],
-			Index:    idx,
-			PosIndex: idx,
+
+	ordinary := 0
+	last := -1
+
+	for i := 0; i < len(str); i++ {
+		if str[i] != '%' {
+			continue
 		}
-		overloadedIndex := spec.index()
-		if overloadedIndex > -1 {
-			spec.Index = overloadedIndex
+
+		spec, ok := scanJavaPrintf(str, i)
+		if !ok {
+			continue
 		}
-		if spec.String() == "%%" {
+
+		i = spec.End - 1
+
+		switch verb := spec.Verb(); {
+		case verb == '%' || verb == 'n':
 			continue
+		case spec.Relative:
+			if last < 0 {
+				continue
+			}
+
+			spec.Arg = last
+		case !spec.Indexed():
+			spec.Arg = ordinary
+			ordinary++
 		}
+
+		last = spec.Arg
+		spec.PosIndex = len(specs)
 		specs = append(specs, spec)
-		idx++
 	}
-	sort.Sort(pfsSortByIndex(specs))
+
+	sort.Stable(pfsSortByIndex(specs))
 	// enumerate cleanly
 	for i := range specs {
 		specs[i].Index = i
 	}
+
 	return specs
 }
 
+// scanJavaPrintf scans the directive at pos. The argument index is only set for the $ notation.
+func scanJavaPrintf(str string, pos int) (PrintfFormatSpecifier, bool) {
+	spec := PrintfFormatSpecifier{Src: str, Pos: pos, Width: -1, Precision: -1}
+	i := pos + 1
+
+	// an argument index is a number followed by $, otherwise the digits are the width
+	if num, end := javaNum(str, i); end > i && end < len(str) && str[end] == '$' {
+		if num < 1 {
+			return spec, false
+		}
+
+		spec.Arg = num - 1
+		i = end + 1
+	}
+
+	flagsStart := i
+
+	var flags strings.Builder
+
+	for ; i < len(str) && (strings.IndexByte(javaFlags, str[i]) >= 0 || str[i] == '<'); i++ {
+		if str[i] == '<' {
+			spec.Relative = true
+		} else {
+			flags.WriteByte(str[i])
+		}
+	}
+
+	if spec.Relative && flagsStart > pos+1 {
+		// a relative index may not be combined with an explicit one
+		return spec, false
+	}
+
+	spec.Flags = flags.String()
+
+	if num, end := javaNum(str, i); end > i {
+		spec.Width = num
+		i = end
+	}
+
+	if i < len(str) && str[i] == '.' {
+		num, end := javaNum(str, i+1)
+		if end == i+1 {
+			return spec, false
+		}
+
+		spec.Precision = num
+		i = end
+	}
+
+	if i >= len(str) {
+		return spec, false
+	}
+
+	switch c := str[i]; {
+	case c == 't' || c == 'T':
+		if i+1 >= len(str) || strings.IndexByte(javaDateTimeSuffixes, str[i+1]) < 0 {
+			return spec, false
+		}
+
+		spec.DateTime = str[i+1]
+		spec.End = i + 2
+	case strings.IndexByte(javaConversions, c) >= 0:
+		spec.End = i + 1
+	default:
+		return spec, false
+	}
+
+	return spec, true
+}
+
+// javaNum parses the decimal number at start and returns the end of the digits
+func javaNum(str string, start int) (int, int) {
+	end := start
+	for end < len(str) && '0' <= str[end] && str[end] <= '9' {
+		end++
+	}
+
+	num, err := strconv.Atoi(str[start:end])
+	if err != nil {
+		return -1, end
+	}
+
+	return num, end
+}
+
 type pfsSortByIndex []PrintfFormatSpecifier
 
 func (p pfsSortByIndex) Len() int {
@@ -112,7 +389,7 @@ func (p pfsSortByIndex) Len() int {
 }
 
 func (p pfsSortByIndex) Less(i, j int) bool {
-	return p[i].Index < p[j].Index
+	return p[i].Arg < p[j].Arg
 }
 
 func (p pfsSortByIndex) Swap(i, j int) {
@@ -15,7 +15,9 @@
 package android
 
 import (
+	"errors"
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -56,7 +58,8 @@ func TestParsePrintf(t *testing.T) {
 		{"print the ascii character, same as chr() function", "%%c = '%c'", "%c"},
 		{"standard integer representation", "%%d = '%d'", "%d"},
 		{"scientific notation", "%%e = '%e'", "%e"},
-		{"integer representation", "%%u = '%u'", "%u"},
+		{"grouping separator", "%%,d = '%,d'", "%,d"},
+		{"negative numbers in parentheses", "%%(d = '%(d'", "%(d"},
 		{"floating point representation", "%%f = '%f'", "%f"},
 		{"octal representation", "%%o = '%o'", "%o"},
 		{"string representation", "%%s = '%s'", "%s"},
@@ -69,14 +72,138 @@ func TestParsePrintf(t *testing.T) {
 		{"right-justification with spaces", "[%10s]", "%10s"},
 		{"left-justification with spaces", "[%-10s]", "%-10s"},
 		{"zero-padding works on strings too", "[%010s]", "%010s"},
-		{"use the custom padding character '#'", "[%'#10s]", "%'#10s"},
+		{"alternate form", "[%#10x]", "%#10x"},
+		{"relative index", "%1$s and %<s", "%<s"},
+		{"date conversion", "year %tY", "%tY"},
+		{"upper case string", "%S", "%S"},
 		{"left-justification but with a cutoff of 10 characters", "[%10.10s]", "%10.10s"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := ParsePrintf(tt.args)[0].String(); !reflect.DeepEqual(got, tt.want) {
+			specs := ParsePrintf(tt.args)
+			if got := specs[len(specs)-1].String(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ParsePrintf() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestParsePrintfFields(t *testing.T) {
+	specs := ParsePrintf("%2$-,10.2f %s %<S %tY %s")
+	if len(specs) != 5 {
+		t.Fatalf("expected 5 specifiers but got %d", len(specs))
+	}
+
+	sort.Sort(pfsSortByPosIndex(specs))
+
+	if s := specs[0]; s.Arg != 1 || s.Flags != "-," || s.Width != 10 || s.Precision != 2 || s.Verb() != 'f' {
+		t.Fatalf("unexpected %+v", s)
+	}
+
+	// the ordinary index is independent of the explicit one
+	if s := specs[1]; s.Arg != 0 || s.Width != -1 || s.Precision != -1 {
+		t.Fatalf("unexpected %+v", s)
+	}
+
+	if s := specs[2]; s.Arg != 0 || !s.Relative || s.Verb() != 'S' {
+		t.Fatalf("unexpected %+v", s)
+	}
+
+	if s := specs[3]; s.Arg != 1 || s.Verb() != 't' || s.DateTime != 'Y' {
+		t.Fatalf("unexpected %+v", s)
+	}
+
+	if s := specs[4]; s.Arg != 2 {
+		t.Fatalf("unexpected %+v", s)
+	}
+}
+
+func TestParsePrintfInvalid(t *testing.T) {
+	for _, str := range []string{"100%%", "line%n", "%u", "%0$s", "%<s", "%.f", "%tW", "%", "%1$<s"} {
+		if specs := ParsePrintf(str); len(specs) != 0 {
+			t.Fatalf("expected no specifiers in '%s' but got %v", str, specs)
+		}
+	}
+}
+
+func TestAsGoFormat(t *testing.T) {
+	tests := []struct {
+		java string
+		want string
+	}{
+		{"%s and %d", "%s and %d"},
+		{"%2$s and %1$d", "%[2]s and %[1]d"},
+		{"%2$s and %s", "%[2]s and %[1]s"},
+		{"%s and %<s", "%s and %[1]s"},
+		{"first%nsecond 100%%", "first\nsecond 100%%"},
+		{"%1$-10.2f", "%-10.2[1]f"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.java, func(t *testing.T) {
+			got, err := AsGoFormat(tt.java)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAsGoFormatUnsupported(t *testing.T) {
+	tests := []struct {
+		java string
+		want string
+	}{
+		{"%,d items", "%,d"},
+		{"%s and %2$(.2f", "%2$(.2f"},
+		{"in %tY", "%tY"},
+		{"at %1$TH", "%1$TH"},
+		{"%S", "%S"},
+		{"%h", "%h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.java, func(t *testing.T) {
+			_, err := AsGoFormat(tt.java)
+
+			var unsupported ErrUnsupportedDirective
+			if !errors.As(err, &unsupported) || unsupported.Directive != tt.want {
+				t.Fatalf("expected the unsupported directive '%s' but got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAsJavaFormat(t *testing.T) {
+	tests := []struct {
+		goStr string
+		want  string
+	}{
+		{"%s and %d", "%s and %d"},
+		{"%[2]s and %[1]d", "%2$s and %1$d"},
+		{"%-[1]s", "%1$-s"},
+		{"%-10.2[1]f", "%1$-10.2f"},
+		{"100%% and %[x]s", "100%% and %[x]s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goStr, func(t *testing.T) {
+			if got := AsJavaFormat(tt.goStr); got != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, got)
+			}
+
+			indexed, err := AsGoFormat(AsJavaFormat(tt.goStr))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.goStr != "%-[1]s" && indexed != tt.goStr {
+				t.Fatalf("expected round trip '%s' but got '%s'", tt.goStr, indexed)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const indent = "    "
+
+// Write serializes the android resources as a strings.xml document. To keep diffs reviewable, the output is stable:
+// strings, string-arrays and plurals are each sorted by their name. The texts are expected to be already encoded,
+// see also Encode.
+func Write(writer io.Writer, res Resources) error {
+	strs := append([]String(nil), res.Strings...)
+	sort.SliceStable(strs, func(i, j int) bool {
+		return strs[i].Name < strs[j].Name
+	})
+
+	arrays := append([]StringArray(nil), res.StringArrays...)
+	sort.SliceStable(arrays, func(i, j int) bool {
+		return arrays[i].Name < arrays[j].Name
+	})
+
+	plurals := append([]Plurals(nil), res.Plurals...)
+	sort.SliceStable(plurals, func(i, j int) bool {
+		return plurals[i].Name < plurals[j].Name
+	})
+
+	w := bufio.NewWriter(writer)
+	_, _ = w.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n<resources" +
+		toolsNamespace(strs, arrays, plurals) + ">\n")
+
+	for _, str := range strs {
+		_, _ = w.WriteString(indent + `<string name="` + escapeAttr(str.Name) + `"` + translatable(str.Translatable) +
+			format(str.Format) + ">" + escapeXML(str.Text) + "</string>\n")
+	}
+
+	for _, arr := range arrays {
+		_, _ = w.WriteString(indent + `<string-array name="` + escapeAttr(arr.Name) + `"` + translatable(arr.Translatable) +
+			list(arr.List) + selectors(arr.Select) + ">\n")
+		for _, item := range arr.Items {
+			_, _ = w.WriteString(indent + indent + "<item>" + escapeXML(item) + "</item>\n")
+		}
+
+		_, _ = w.WriteString(indent + "</string-array>\n")
+	}
+
+	for _, pl := range plurals {
+		_, _ = w.WriteString(indent + `<plurals name="` + escapeAttr(pl.Name) + `"` + ordinal(pl.Ordinal) + ">\n")
+		for _, item := range pl.Items {
+			_, _ = w.WriteString(indent + indent + `<item quantity="` + escapeAttr(item.Quantity) + `">` +
+				escapeXML(item.Text) + "</item>\n")
+		}
+
+		_, _ = w.WriteString(indent + "</plurals>\n")
+	}
+
+	_, _ = w.WriteString("</resources>\n")
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write xml: %w", err)
+	}
+
+	return nil
+}
+
+// WriteFile serializes the android resources into a strings.xml file
+func WriteFile(fname string, res Resources) error {
+	file, err := os.Create(fname)
+	if err != nil {
+		return fmt.Errorf("cannot create '%s':%w", fname, err)
+	}
+
+	err = Write(file, res)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("cannot close '%s':%w", fname, err)
+	}
+
+	return nil
+}
+
+// Remove deletes the strings, string-arrays and plurals with the given names from the strings.xml document. In
+// contrast to Write, everything else, like the order, comments and the formatting, is kept as is. The line of a
+// removed element is removed entirely, if it only contains that element.
+func Remove(src []byte, names map[string]bool) ([]byte, error) {
+	dec := xml.NewDecoder(bytes.NewReader(src))
+	dst := make([]byte, 0, len(src))
+	depth, last := 0, 0
+
+	for {
+		start := int(dec.InputOffset())
+
+		token, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse xml: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth != 1 || !names[attr(t, "name")] {
+				depth++
+				continue
+			}
+
+			if err := dec.Skip(); err != nil {
+				return nil, fmt.Errorf("failed to parse xml: %w", err)
+			}
+
+			start, end := lineOf(src, start, int(dec.InputOffset()))
+			dst = append(dst, src[last:start]...)
+			last = end
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	return append(dst, src[last:]...), nil
+}
+
+// attr returns the value of the attribute without namespace or the empty string
+func attr(elem xml.StartElement, name string) string {
+	for _, a := range elem.Attr {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// lineOf widens the range to its entire line including the line break, if nothing else is on that line
+func lineOf(src []byte, start, end int) (int, int) {
+	lineStart := start
+	for lineStart > 0 && (src[lineStart-1] == ' ' || src[lineStart-1] == '\t') {
+		lineStart--
+	}
+
+	lineEnd := end
+	for lineEnd < len(src) && (src[lineEnd] == ' ' || src[lineEnd] == '\t' || src[lineEnd] == '\r') {
+		lineEnd++
+	}
+
+	if (lineStart == 0 || src[lineStart-1] == '\n') && (lineEnd == len(src) || src[lineEnd] == '\n') {
+		if lineEnd < len(src) {
+			lineEnd++
+		}
+
+		return lineStart, lineEnd
+	}
+
+	return start, end
+}
+
+// Encode is the inverse of Decode: it escapes the special chars and replaces go indices like %[1]s with the
+// android notation %1$s.
+func Encode(goStr string) string {
+	return AsJavaFormat(Escape(goStr))
+}
+
+// Escape is the inverse of Unescape: it only escapes the special chars.
+func Escape(str string) string {
+	sb := &strings.Builder{}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		escaped := false
+
+		for _, special := range specials {
+			if special.c == c {
+				sb.WriteString(special.escaped)
+				escaped = true
+
+				break
+			}
+		}
+
+		if !escaped {
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String()
+}
+
+func translatable(t *bool) string {
+	if t == nil {
+		return ""
+	}
+
+	return ` translatable="` + strconv.FormatBool(*t) + `"`
+}
+
+// escapeXML only escapes what is required within character data, so that the android escapes of quotes are kept
+// readable.
+func escapeXML(str string) string {
+	return textEscaper.Replace(str)
+}
+
+// escapeAttr escapes an attribute value which is always quoted by ".
+func escapeAttr(str string) string {
+	return attrEscaper.Replace(str)
+}
+
+// nolint: gochecknoglobals
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// toolsNamespace declares the tools namespace, if any string has a format, any array is a list or select or any
+// plural is an ordinal
+func toolsNamespace(strs []String, arrays []StringArray, plurals []Plurals) string {
+	for _, str := range strs {
+		if str.Format != "" {
+			return ` xmlns:tools="` + ToolsNamespace + `"`
+		}
+	}
+
+	for _, arr := range arrays {
+		if arr.List != "" || arr.Select != "" {
+			return ` xmlns:tools="` + ToolsNamespace + `"`
+		}
+	}
+
+	for _, pl := range plurals {
+		if pl.Ordinal {
+			return ` xmlns:tools="` + ToolsNamespace + `"`
+		}
+	}
+
+	return ""
+}
+
+func ordinal(ordinal bool) string {
+	if ordinal {
+		return ` tools:ordinal="true"`
+	}
+
+	return ""
+}
+
+func format(format string) string {
+	if format != "" {
+		return ` tools:format="` + escapeAttr(format) + `"`
+	}
+
+	return ""
+}
+
+func list(list string) string {
+	if list != "" {
+		return ` tools:list="` + escapeAttr(list) + `"`
+	}
+
+	return ""
+}
+
+func selectors(selectors string) string {
+	if selectors != "" {
+		return ` tools:select="` + escapeAttr(selectors) + `"`
+	}
+
+	return ""
+}
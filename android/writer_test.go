@@ -0,0 +1,132 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package android
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"special chars escape", `@ ? < & ' "`, `\@ \? < & \' \"`},
+		{"conversion with indices", `hello %%1$s %s %[2]d %[13]s`, `hello %%1$s %s %2$d %13$s`},
+		{"flags before index", `%-[1]10s %+[2]d`, `%1$-10s %2$+d`},
+		{"trailing percent", `100%`, `100%`},
+	}
+	// nolint: scopelint // tt is a value, so this is a false-positive
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Encode(tt.args); got != tt.want {
+				t.Errorf("Encode() = %v, want %v", got, tt.want)
+			}
+
+			got, err := Decode(Encode(tt.args))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.args && tt.name != "flags before index" {
+				t.Errorf("Decode(Encode()) = %v, want %v", got, tt.args)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	res, err := ReadFile("strings_test.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Write(buf, res); err != nil {
+		t.Fatal(err)
+	}
+
+	res2, err := Read(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf2 := &bytes.Buffer{}
+	if err := Write(buf2, res2); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != buf2.String() {
+		t.Fatalf("expected stable output but got\n%s\n%s", buf.String(), buf2.String())
+	}
+
+	if len(res2.Strings) != len(res.Strings) || len(res2.Plurals) != len(res.Plurals) ||
+		len(res2.StringArrays) != len(res.StringArrays) {
+		t.Fatalf("lost elements:\n%s", buf.String())
+	}
+
+	if res2.Strings[0].Name != "app_name" || res2.Strings[0].Translatable == nil || *res2.Strings[0].Translatable {
+		t.Fatalf("unexpected first string %+v", res2.Strings[0])
+	}
+
+	if !strings.Contains(buf.String(), `<resources xmlns:tools="`+ToolsNamespace+`">`) ||
+		!strings.Contains(buf.String(), `<plurals name="nth_order" tools:ordinal="true">`) {
+		t.Fatalf("expected an ordinal plural:\n%s", buf.String())
+	}
+
+	for _, pl := range res2.Plurals {
+		if pl.Ordinal != (pl.Name == "nth_order") {
+			t.Fatalf("unexpected ordinal %+v", pl)
+		}
+	}
+}
+
+func TestRemove(t *testing.T) {
+	src := `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <!-- the greeting -->
+    <string name="hello_x">Hello %s</string>
+    <string name="unused">unused</string>
+    <string-array name="days"><item>Monday</item></string-array>
+	<plurals name="x_cats">
+        <item quantity="one">%d cat</item>
+        <item quantity="other">%d cats</item>
+    </plurals><string name="inline">inline</string>
+</resources>
+`
+
+	res, err := Remove([]byte(src), map[string]bool{"unused": true, "days": true, "x_cats": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <!-- the greeting -->
+    <string name="hello_x">Hello %s</string>
+	<string name="inline">inline</string>
+</resources>
+`
+	if string(res) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(res))
+	}
+
+	if _, err := Remove([]byte("<resources><string>"), nil); err == nil {
+		t.Fatal("expected a syntax error")
+	}
+}
@@ -16,113 +16,121 @@ package i18n
 
 import (
 	"fmt"
-	"github.com/golangee/i18n/internal"
 	"github.com/golangee/log"
 	"github.com/golangee/log/ecs"
+	"golang.org/x/text/language"
 	"io"
-	"os"
-	"sort"
+	"io/fs"
 )
 
 // ErrTextNotFound is the sentinel error for a named string which is not available
 var ErrTextNotFound = fmt.Errorf("string not found")
 
-var allResources = newLocalizations() //nolint: gochecknoglobals
+// ErrLocaleNotFound is the sentinel error for a locale which has not been imported
+var ErrLocaleNotFound = fmt.Errorf("locale not found")
 
-var logger = log.NewLogger(ecs.Log("i18n"))
+// ErrNoMatch is the sentinel error for wanted locales which are not matched confidently enough
+var ErrNoMatch = fmt.Errorf("no matching locale")
 
-// Import takes the importer and locale and updates the according internal localization resources.
-// The order of import is relevant, because it determines the fallback matching logic. Import your default fallback
-// language first.
-func Import(importer Importer, locale string, src io.Reader) error {
-	res := allResources.Configure(locale)
-	err := importer.Import(res, src)
+var defaultBundle = NewBundle() //nolint: gochecknoglobals
 
-	if err != nil {
-		return fmt.Errorf("failed to parse: %w", err)
-	}
+var logger = log.NewLogger(ecs.Log("i18n"))
 
-	return nil
+// Import takes the importer and locale and updates the according internal localization resources of the default
+// bundle. The order of import is relevant, because it determines the fallback matching logic. Import your default
+// fallback language first.
+func Import(importer Importer, locale string, src io.Reader) error {
+	return defaultBundle.Import(importer, locale, src)
 }
 
 // ImportFile is a convenience method for Import. It detects the locale from the file name
 func ImportFile(importer Importer, fname string) error {
-	file, err := os.Open(fname)
-	if err != nil {
-		return fmt.Errorf("cannot open file: %w", err)
-	}
+	return defaultBundle.ImportFile(importer, fname)
+}
 
-	defer func() {
-		_ = file.Close()
-	}()
+// ImportFS walks the given file system and imports all matching files into the default bundle,
+// see also Bundle.ImportFS.
+func ImportFS(importer Importer, fsys fs.FS, pattern string) error {
+	return defaultBundle.ImportFS(importer, fsys, pattern)
+}
 
-	return Import(importer, guessLocaleFromFilename(fname), file)
+// Export writes the resources of exactly the given locale of the default bundle using the exporter. It fails if
+// the locale has not been imported.
+func Export(exporter Exporter, locale string, dst io.Writer) error {
+	return defaultBundle.Export(exporter, locale, dst)
 }
 
-// ImportValue adds or replaces any existing value
+// ImportValue adds or replaces any existing value of the default bundle
 func ImportValue(value Value) {
-	res := allResources.Configure(value.Locale())
-	value = value.updateTag(res.tag)
-	res.mutex.Lock()
-	defer res.mutex.Unlock()
+	defaultBundle.ImportValue(value)
+}
 
-	if _, has := res.values[value.ID()]; has {
-		logger.Println(ecs.Warn(), ecs.Msg("replacing already translated value"), log.V("key", value.ID()))
-	}
-	res.values[value.ID()] = value
+// ImportValues adds or replaces any existing values of the default bundle, see also Bundle.ImportValues.
+func ImportValues(values ...Value) {
+	defaultBundle.ImportValues(values...)
 }
 
-// From returns the best matching text Resources to the given set of matching locales
+// From returns the best matching text Resources of the default bundle to the given set of matching locales
 func From(locales ...string) *Resources {
-	return allResources.Match(locales...)
+	return defaultBundle.From(locales...)
+}
+
+// Match returns the best matching text Resources of the default bundle and the confidence, see also Bundle.Match.
+func Match(locales ...string) (*Resources, language.Confidence) {
+	return defaultBundle.Match(locales...)
 }
 
-// Validates checks the current state of the global localizations to see if everything is fine. If no error is returned,
+// MatchStrict returns the best matching text Resources of the default bundle or ErrNoMatch, if the confidence is
+// below the given minimum, see also Bundle.MatchStrict.
+func MatchStrict(minimum language.Confidence, locales ...string) (*Resources, error) {
+	return defaultBundle.MatchStrict(minimum, locales...)
+}
+
+// Validates checks the current state of the default bundle to see if everything is fine. If no error is returned,
 // you can be sure that at least every key is translated in every language and the printf directives are consistent
 // with each other.
 func Validate() error {
-	allResources.translationsMutex.RLock()
-	defer allResources.translationsMutex.RUnlock()
-
-	tmp := make([]*Resources, 0, len(allResources.translations))
-	for _, res := range allResources.translations {
-		tmp = append(tmp, res)
-	}
-	return validate(tmp)
+	return defaultBundle.Validate()
 }
 
-// TranslationPriority updates the resolution order and removes unwanted translations. "und" is the undefined default
-// locale.
+// TranslationPriority updates the resolution order of the default bundle and removes unwanted translations. "und"
+// is the undefined default locale.
 func TranslationPriority(locales ...string) {
-	allResources.SetTranslationPriority(locales)
+	defaultBundle.TranslationPriority(locales...)
 }
 
-// Locales returns all translated locales
+// SetFallbackChain updates the chain of the default bundle, see also Bundle.SetFallbackChain.
+func SetFallbackChain(chain FallbackChain) {
+	defaultBundle.SetFallbackChain(chain)
+}
+
+// Locales returns all translated locales of the default bundle
 func Locales() []string {
-	var res []string
-	for k, _ := range allResources.translations {
-		res = append(res, k.String())
-	}
-	sort.Strings(res)
-	return res
+	return defaultBundle.Locales()
 }
 
-// Bundle (re)generates all localizations in the current working directory.
-func Bundle() error {
-	dir, err := internal.ModRootDir()
-	if err != nil {
-		return fmt.Errorf("unable to get current working directory: %w", err)
-	}
-	fmt.Println(dir)
-	gen := newGoGenerator(dir)
-	err = gen.Scan()
+// DefaultBundle returns the bundle which is used by the package level functions and by the generated code, if
+// not registered otherwise.
+func DefaultBundle() *Bundle {
+	return defaultBundle
+}
+
+// Generate (re)generates all localizations in the module of the current working directory.
+func Generate() error {
+	return GenerateWith(ModuleOptions{})
+}
+
+// GenerateWith (re)generates the localizations of the selected strings files of the module.
+func GenerateWith(opts ModuleOptions) error {
+	t, err := Scan(opts)
 	if err != nil {
-		return fmt.Errorf("cannot scan module: %w", err)
+		return err
 	}
 
-	err = gen.Emit()
+	err = t.Emit()
 	if err != nil {
 		return fmt.Errorf("unable to generate source code: %w", err)
 	}
+
 	return nil
 }
@@ -15,11 +15,17 @@
 package i18n
 
 import (
+	"errors"
+	"github.com/golangee/i18n/android"
+	"golang.org/x/text/language"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func setup() {
-	allResources = newLocalizations()
+	defaultBundle = NewBundle()
 }
 
 func TestImport(t *testing.T) {
@@ -100,3 +106,338 @@ func TestChecker(t *testing.T) {
 		t.Fatal(len(errs), "fails")
 	}
 }
+
+func TestImportFS(t *testing.T) {
+	setup()
+
+	fsys := fstest.MapFS{
+		"res/strings-de-DE.xml": {Data: []byte(`<resources><string name="hello">Hallo</string></resources>`)},
+		"res/strings.xml":       {Data: []byte(`<resources><string name="hello">Hello</string></resources>`)},
+		"res/ignored.txt":       {Data: []byte(`hello`)},
+		"strings-fr.xml":        {Data: []byte(`<resources><string name="hello">Bonjour</string></resources>`)},
+	}
+
+	if err := ImportFS(AndroidImporter{}, fsys, "strings*.xml"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the import order determines the fallback
+	if expected := []language.Tag{language.Und, language.MustParse("de-DE"), language.French}; !reflect.DeepEqual(
+		defaultBundle.res.load().translationPriority, expected) {
+		t.Fatalf("expected %v but got %v", expected, defaultBundle.res.load().translationPriority)
+	}
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"de", "Hallo"},
+		{"fr", "Bonjour"},
+		{"ja", "Hello"},
+	}
+
+	for _, tt := range tests {
+		str, err := From(tt.locale).Text("hello")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if str != tt.want {
+			t.Fatalf("%s: expected '%s' but got '%s'", tt.locale, tt.want, str)
+		}
+	}
+
+	setup()
+
+	if err := ImportFS(AndroidImporter{}, fsys, "res/*.xml"); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"de-DE", "und"}; !reflect.DeepEqual(Locales(), expected) {
+		t.Fatalf("expected %v but got %v", expected, Locales())
+	}
+
+	if err := ImportFS(AndroidImporter{}, fsys, "res/*.txt"); err == nil {
+		t.Fatal("expected import error")
+	}
+
+	// the origin is the path within the file system
+	if origin := From("de-DE").Value("hello").Origin().String(); origin != "res/strings-de-DE.xml:1:12" {
+		t.Fatalf("unexpected origin '%s'", origin)
+	}
+}
+
+func TestOriginString(t *testing.T) {
+	tests := []struct {
+		origin Origin
+		want   string
+	}{
+		{Origin{}, ""},
+		{Origin{File: "strings.xml"}, "strings.xml"},
+		{Origin{File: "strings.xml", Line: 2, Column: 5}, "strings.xml:2:5"},
+		{Origin{Line: 2, Column: 5}, "2:5"},
+	}
+
+	for _, tt := range tests {
+		if str := tt.origin.String(); str != tt.want {
+			t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+		}
+	}
+}
+
+func TestOrdinalText(t *testing.T) {
+	setup()
+
+	ImportValue(NewOrdinalText("en", "nth_order").One("your %dst order").Two("your %dnd order").
+		Few("your %drd order").Other("your %dth order"))
+	ImportValue(NewOrdinalText("fr", "nth_order").One("ta %dre commande").Other("ta %de commande"))
+
+	err := Import(AndroidImporter{}, "de", strings.NewReader(`<resources xmlns:tools="http://schemas.android.com/tools">
+    <plurals name="nth_order" tools:ordinal="true"><item quantity="other">deine %d. Bestellung</item></plurals>
+</resources>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = Import(ARBImporter{}, "it", strings.NewReader(`{
+  "nth_order": "{n, selectordinal, many{il tuo {n}º ordine} other{il tuo {n}° ordine}}"
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		locale  string
+		ordinal int
+		want    string
+	}{
+		{"en", 1, "your 1st order"},
+		{"en", 2, "your 2nd order"},
+		{"en", 3, "your 3rd order"},
+		{"en", 4, "your 4th order"},
+		{"en", 11, "your 11th order"},
+		{"en", 12, "your 12th order"},
+		{"en", 22, "your 22nd order"},
+		{"en", 103, "your 103rd order"},
+		{"fr", 1, "ta 1re commande"},
+		{"fr", 2, "ta 2e commande"},
+		{"de", 2, "deine 2. Bestellung"},
+		{"it", 8, "il tuo 8º ordine"},
+		{"it", 9, "il tuo 9° ordine"},
+	}
+
+	for _, tt := range tests {
+		str, err := From(tt.locale).OrdinalText("nth_order", tt.ordinal, tt.ordinal)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if str != tt.want {
+			t.Fatalf("%s: expected '%s' but got '%s'", tt.locale, tt.want, str)
+		}
+	}
+
+	ImportValue(NewQuantityText("und", "nth_order").Other("%d orders"))
+
+	if err := Validate(); err == nil || !strings.Contains(err.Error(), "pluralValue (ordinal) in") {
+		t.Fatalf("expected a type mismatch but got %v", err)
+	}
+}
+
+func TestSelectText(t *testing.T) {
+	setup()
+
+	ImportValue(NewSelectText("en", "x_invited_you").Case("female", "%s invited you to her party").
+		Case("male", "%s invited you to his party").Other("%s invited you to their party"))
+
+	err := Import(ARBImporter{}, "de", strings.NewReader(`{
+  "x_invited_you": "{name} hat dich zu {gender, select, female{ihrer} male{seiner} other{der}} Party eingeladen"
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		locale   string
+		selector string
+		want     string
+	}{
+		{"en", "female", "Nick invited you to her party"},
+		{"en", "male", "Nick invited you to his party"},
+		{"en", "other", "Nick invited you to their party"},
+		{"en", "unknown", "Nick invited you to their party"},
+		{"de", "female", "Nick hat dich zu ihrer Party eingeladen"},
+		{"de", "male", "Nick hat dich zu seiner Party eingeladen"},
+		{"de", "", "Nick hat dich zu der Party eingeladen"},
+	}
+
+	for _, tt := range tests {
+		str, err := From(tt.locale).SelectText("x_invited_you", tt.selector, "Nick")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if str != tt.want {
+			t.Fatalf("%s: expected '%s' but got '%s'", tt.locale, tt.want, str)
+		}
+	}
+
+	// the builder is a value and must not share its cases
+	base := NewSelectText("en", "x").Case("a", "A").Other("O")
+	_ = base.Case("a", "changed").Case("b", "B")
+
+	if str, _ := base.SelectText("a"); str != "A" {
+		t.Fatalf("expected 'A' but got '%s'", str)
+	}
+
+	if str, _ := base.SelectText("b"); str != "O" {
+		t.Fatalf("expected 'O' but got '%s'", str)
+	}
+}
+
+func TestValidateSelect(t *testing.T) {
+	tests := []struct {
+		name  string
+		value Value
+		want  string
+	}{
+		{"valid", NewSelectText("de", "x").Case("female", "sie %s").Case("male", "er %s").Other("es %s"), ""},
+		{"missing case", NewSelectText("de", "x").Case("female", "sie %s").Other("es %s"), "the case 'male'"},
+		{"extra case", NewSelectText("de", "x").Case("female", "sie %s").Case("male", "er %s").
+			Case("diverse", "%s").Other("es %s"), "the case 'diverse'"},
+		{"missing other", NewSelectText("de", "x").Case("female", "sie %s").Case("male", "er %s"), "select 'other'"},
+		{"verb conflict", NewSelectText("de", "x").Case("female", "sie %d").Case("male", "er %s").Other("es %s"),
+			"the verb 'd'"},
+		{"case count", NewSelectText("de", "x").Case("female", "sie").Case("male", "er %s").Other("es %s"),
+			"argument count mismatch"},
+		{"type mismatch", NewText("de", "x", "%s"), "is a i18n.selectValue in en but in de a i18n.simpleValue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			ImportValue(NewSelectText("en", "x").Case("female", "she %s").Case("male", "he %s").Other("they %s"))
+			ImportValue(tt.value)
+
+			err := Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected '%s' but got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateOrdinal(t *testing.T) {
+	tests := []struct {
+		name  string
+		value Value
+		want  string
+	}{
+		{"valid", NewOrdinalText("fr", "x").One("ta %dre commande").Other("ta %de commande"), ""},
+		{"missing few", NewOrdinalText("fr", "x").One("ta %dre commande").Few("ta commande").
+			Other("ta %de commande"), "argument count mismatch"},
+		{"verb conflict", NewOrdinalText("fr", "x").Few("ta %sre commande").Other("ta %de commande"),
+			"the verb 's'"},
+		{"missing other", NewOrdinalText("fr", "x").One("ta %dre commande"), "plural 'other'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			ImportValue(NewOrdinalText("en", "x").One("your %dst order").Two("your %dnd order").
+				Few("your %drd order").Other("your %dth order"))
+			ImportValue(tt.value)
+
+			err := Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected '%s' but got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestValidatePlural(t *testing.T) {
+	tests := []struct {
+		name  string
+		value Value
+		want  string
+	}{
+		{"one without directive", NewQuantityText("de", "x").One("eine Katze").Other("%d Katzen"), ""},
+		{"other categories", NewQuantityText("ru", "x").Few("%d кошки").Many("%d кошек").
+			Other("%d кошки"), ""},
+		{"other", NewQuantityText("ru", "x").One("%d кошка").Few("%d кошки").Other("кошки"),
+			"argument count mismatch"},
+		{"verb conflict", NewQuantityText("de", "x").One("eine Katze").Other("%s Katzen"), "the verb 's'"},
+		{"missing other", NewQuantityText("de", "x").One("eine Katze"), "plural 'other'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			ImportValue(NewQuantityText("en", "x").One("one cat").Other("%d cats"))
+			ImportValue(tt.value)
+
+			err := Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected '%s' but got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestImportUnsupportedDirective(t *testing.T) {
+	tests := []struct {
+		name     string
+		importer Importer
+		src      string
+	}{
+		{"android grouping", AndroidImporter{}, `<resources><string name="a">a</string>` +
+			`<string name="b">%,d items</string></resources>`},
+		{"android date", AndroidImporter{}, `<resources><string name="a">a</string>` +
+			`<plurals name="b"><item quantity="other">%d in %tY</item></plurals></resources>`},
+		{"android parentheses", AndroidImporter{}, `<resources><string name="a">a</string>` +
+			`<string-array name="b"><item>%(.2f</item></string-array></resources>`},
+		{"po", POImporter{}, "msgid \"a\"\nmsgstr \"a\"\n\nmsgid \"%,d items\"\nmsgstr \"%,d Dinge\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+
+			err := Import(tt.importer, "de", strings.NewReader(tt.src))
+
+			var unsupported android.ErrUnsupportedDirective
+			if !errors.As(err, &unsupported) {
+				t.Fatalf("expected an unsupported directive but got %v", err)
+			}
+
+			if res := From("de"); res.Value("a") != nil {
+				t.Fatalf("expected nothing to be imported but got %v", res.Keys())
+			}
+		})
+	}
+}
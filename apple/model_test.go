@@ -0,0 +1,160 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apple
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestReadStringsFile(t *testing.T) {
+	strs, err := ReadStringsFile("Localizable_test.strings")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(strs) != 7 {
+		t.Fatalf("expected 7 strings but got %+v", strs)
+	}
+
+	if strs[0].Key != "app_name" || strs[0].Value != "LeichteApp" || strs[0].Comment != "The name of the app" {
+		t.Fatalf("unexpected first string %+v", strs[0])
+	}
+
+	if strs[1].Comment != "a line comment" || strs[2].Comment != "" {
+		t.Fatalf("unexpected comments %+v", strs[1:3])
+	}
+
+	if expected := "a \"quoted\"\ttab\nline ä 100%%"; strs[3].Value != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, strs[3].Value)
+	}
+
+	if strs[4].Key != "unquoted_key" {
+		t.Fatalf("unexpected key %s", strs[4].Key)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteStrings(buf, strs); err != nil {
+		t.Fatal(err)
+	}
+
+	strs2, err := ReadStrings(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(strs2) != len(strs) || strs2[0].Key != "app_name" || strs2[1].Key != "escapes" ||
+		strs2[1].Value != strs[3].Value {
+		t.Fatalf("unexpected round trip:\n%s", buf.String())
+	}
+}
+
+func TestReadStringsUTF16(t *testing.T) {
+	src := utf16.Encode([]rune("\uFEFF\"key\" = \"wert ä\";"))
+	buf := &bytes.Buffer{}
+
+	for _, c := range src {
+		buf.WriteByte(byte(c))
+		buf.WriteByte(byte(c >> 8))
+	}
+
+	strs, err := ReadStrings(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(strs, []String{{Key: "key", Value: "wert ä"}}) {
+		t.Fatalf("unexpected %+v", strs)
+	}
+}
+
+func TestReadStringsDictFile(t *testing.T) {
+	plurals, err := ReadStringsDictFile("Localizable_test.stringsdict")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plurals) != 2 {
+		t.Fatalf("expected 2 plurals but got %+v", plurals)
+	}
+
+	found := plurals[0]
+	if found.Key != "found_files" || found.Variable != "files" {
+		t.Fatalf("unexpected plural %+v", found)
+	}
+
+	if expected := map[string]string{"one": "eine Datei in Ordnern gefunden", "other": "%d Dateien in Ordnern gefunden"}; !reflect.DeepEqual(found.Texts(), expected) {
+		t.Fatalf("expected %v but got %v", expected, found.Texts())
+	}
+
+	buf := &bytes.Buffer{}
+	if err := WriteStringsDict(buf, plurals[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	plurals2, err := ReadStringsDict(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(plurals2, plurals[1:]) {
+		t.Fatalf("expected %+v but got %+v\n%s", plurals[1:], plurals2, buf.String())
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"object", `hello %@`, `hello %s`},
+		{"indexed object", `%2$@ and %1$@`, `%[2]s and %[1]s`},
+		{"length modifiers", `%ld %lu %lld %2$lu %hhd`, `%d %d %d %[2]d %d`},
+		{"width and precision", `%05.2f %-10@ %1$+d`, `%05.2f %-10s %[1]+d`},
+		{"escaped percent", `100%% %`, `100%% %`},
+		{"unknown verb", `%k`, `%k`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Decode(tt.args); got != tt.want {
+				t.Errorf("Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"string", `hello %s`, `hello %@`},
+		{"indexed", `%[2]s and %[1]d`, `%2$@ and %1$d`},
+		{"flags before index", `%-[1]10s`, `%1$-10@`},
+		{"value", `%v %05.2f`, `%@ %05.2f`},
+		{"escaped percent", `100%% %`, `100%% %`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Encode(tt.args); got != tt.want {
+				t.Errorf("Encode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apple
+
+import (
+	"strings"
+)
+
+// specifier is a parsed printf directive, which is either in the apple or in the go notation.
+type specifier struct {
+	index     string // index is the positional argument, like 1 for %1$@ or %[1]s
+	flags     string
+	width     string
+	precision string // precision includes the dot
+	verb      byte
+	end       int // end is the index after the verb
+}
+
+// appleVerbs maps the apple (IEEE printf and NSString) conversions onto go verbs
+// nolint: gochecknoglobals
+var appleVerbs = map[byte]byte{
+	'@': 's',
+	's': 's',
+	'S': 's',
+	'd': 'd',
+	'D': 'd',
+	'i': 'd',
+	'u': 'd',
+	'U': 'd',
+	'o': 'o',
+	'O': 'o',
+	'x': 'x',
+	'X': 'X',
+	'f': 'f',
+	'F': 'f',
+	'e': 'e',
+	'E': 'E',
+	'g': 'g',
+	'G': 'G',
+	'a': 'x',
+	'A': 'X',
+	'c': 'c',
+	'C': 'c',
+	'p': 'p',
+}
+
+// goVerbs maps go verbs onto apple conversions, anything else is kept
+// nolint: gochecknoglobals
+var goVerbs = map[byte]byte{
+	's': '@',
+	'v': '@',
+	'q': '@',
+	't': '@',
+}
+
+// Decode replaces the apple placeholders like %@, %1$@ or %ld with the go notation %s, %[1]s or %d. Unknown
+// directives are kept as they are.
+func Decode(appleStr string) string {
+	sb := &strings.Builder{}
+	for i := 0; i < len(appleStr); i++ {
+		if appleStr[i] != '%' {
+			sb.WriteByte(appleStr[i])
+			continue
+		}
+
+		if i+1 < len(appleStr) && appleStr[i+1] == '%' {
+			sb.WriteString("%%")
+			i++
+
+			continue
+		}
+
+		spec, ok := parseApple(appleStr, i)
+		verb, known := appleVerbs[spec.verb]
+
+		if !ok || !known {
+			sb.WriteByte('%')
+			continue
+		}
+
+		sb.WriteByte('%')
+
+		if spec.index != "" {
+			sb.WriteString("[" + spec.index + "]")
+		}
+
+		sb.WriteString(spec.flags + spec.width + spec.precision)
+		sb.WriteByte(verb)
+
+		i = spec.end - 1
+	}
+
+	return sb.String()
+}
+
+// Encode is the inverse of Decode and replaces go directives like %s or %[1]s with %@ or %1$@.
+func Encode(goStr string) string {
+	sb := &strings.Builder{}
+	for i := 0; i < len(goStr); i++ {
+		if goStr[i] != '%' {
+			sb.WriteByte(goStr[i])
+			continue
+		}
+
+		if i+1 < len(goStr) && goStr[i+1] == '%' {
+			sb.WriteString("%%")
+			i++
+
+			continue
+		}
+
+		spec, ok := parseGo(goStr, i)
+		if !ok {
+			sb.WriteByte('%')
+			continue
+		}
+
+		sb.WriteByte('%')
+
+		if spec.index != "" {
+			sb.WriteString(spec.index + "$")
+		}
+
+		sb.WriteString(spec.flags + spec.width + spec.precision)
+
+		if verb, ok := goVerbs[spec.verb]; ok {
+			sb.WriteByte(verb)
+		} else {
+			sb.WriteByte(spec.verb)
+		}
+
+		i = spec.end - 1
+	}
+
+	return sb.String()
+}
+
+// parseApple parses %[index$][flags][width][.precision][length]conversion
+func parseApple(str string, pos int) (specifier, bool) {
+	spec := specifier{}
+	i := pos + 1
+
+	digits := scan(str, i, isDigit)
+	if digits > i && digits < len(str) && str[digits] == '$' {
+		spec.index = str[i:digits]
+		i = digits + 1
+	}
+
+	i = spec.scanFormat(str, i)
+
+	// length modifiers are meaningless in go
+	i = scan(str, i, func(c byte) bool {
+		return strings.IndexByte("hlqLztj", c) >= 0
+	})
+
+	if i >= len(str) {
+		return spec, false
+	}
+
+	spec.verb = str[i]
+	spec.end = i + 1
+
+	return spec, true
+}
+
+// parseGo parses %[flags][[index]][width][.precision]verb
+func parseGo(str string, pos int) (specifier, bool) {
+	spec := specifier{}
+	i := pos + 1
+
+	flagsEnd := scan(str, i, isFlag)
+	spec.flags = str[i:flagsEnd]
+	i = flagsEnd
+
+	if i < len(str) && str[i] == '[' {
+		end := scan(str, i+1, isDigit)
+		if end == i+1 || end >= len(str) || str[end] != ']' {
+			return spec, false
+		}
+
+		spec.index = str[i+1 : end]
+		i = end + 1
+	}
+
+	flags := spec.flags
+	i = spec.scanFormat(str, i)
+	spec.flags = flags + spec.flags
+
+	if i >= len(str) {
+		return spec, false
+	}
+
+	spec.verb = str[i]
+	spec.end = i + 1
+
+	return spec, true
+}
+
+// scanFormat parses [flags][width][.precision] and returns the next index
+func (s *specifier) scanFormat(str string, i int) int {
+	end := scan(str, i, isFlag)
+	s.flags = str[i:end]
+	i = end
+
+	end = scan(str, i, isDigit)
+	s.width = str[i:end]
+	i = end
+
+	if i < len(str) && str[i] == '.' {
+		end = scan(str, i+1, isDigit)
+		s.precision = str[i:end]
+		i = end
+	}
+
+	return i
+}
+
+func scan(str string, i int, accept func(c byte) bool) int {
+	for i < len(str) && accept(str[i]) {
+		i++
+	}
+
+	return i
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isFlag(c byte) bool {
+	return strings.IndexByte("+-# 0'", c) >= 0
+}
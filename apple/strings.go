@@ -0,0 +1,293 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apple
+
+import (
+	"bufio"
+	"fmt"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// String is a single "key" = "value"; pair of a .strings file
+type String struct {
+	Key     string
+	Value   string
+	Comment string // Comment is the last /* */ or // comment in front of the pair
+}
+
+// ReadStrings parses a .strings file, which is either UTF-8 or UTF-16 with a byte order mark.
+func ReadStrings(reader io.Reader) ([]String, error) {
+	decoder := unicode.BOMOverride(unicode.UTF8.NewDecoder())
+
+	tmp, err := ioutil.ReadAll(transform.NewReader(reader, decoder))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entire strings: %w", err)
+	}
+
+	l := &lexer{src: []rune(string(tmp)), line: 1}
+
+	var res []String
+
+	for {
+		key, ok, err := l.token()
+		if err != nil {
+			return res, err
+		}
+
+		if !ok {
+			return res, nil
+		}
+
+		str := String{Key: key, Value: key, Comment: l.comment}
+
+		switch sep := l.next(); sep {
+		case '=':
+			value, ok, err := l.token()
+			if err != nil {
+				return res, err
+			}
+
+			if !ok {
+				return res, l.errorf("expected value for '%s'", key)
+			}
+
+			str.Value = value
+
+			if l.next() != ';' {
+				return res, l.errorf("expected ';' after value of '%s'", key)
+			}
+		case ';':
+			// the legacy "key"; notation
+		default:
+			return res, l.errorf("expected '=' after '%s' but got '%c'", key, sep)
+		}
+
+		l.comment = ""
+		res = append(res, str)
+	}
+}
+
+// ReadStringsFile parses a .strings file from the file system
+func ReadStringsFile(fname string) ([]String, error) {
+	file, err := os.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open '%s':%w", fname, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return ReadStrings(file)
+}
+
+// WriteStrings serializes the pairs as UTF-8 .strings document, sorted by key.
+func WriteStrings(writer io.Writer, strs []String) error {
+	tmp := append([]String(nil), strs...)
+	sort.SliceStable(tmp, func(i, j int) bool {
+		return tmp[i].Key < tmp[j].Key
+	})
+
+	w := bufio.NewWriter(writer)
+
+	for i, str := range tmp {
+		if i > 0 {
+			_, _ = w.WriteString("\n")
+		}
+
+		if str.Comment != "" {
+			_, _ = w.WriteString("/* " + strings.ReplaceAll(str.Comment, "*/", "* /") + " */\n")
+		}
+
+		_, _ = w.WriteString(quote(str.Key) + " = " + quote(str.Value) + ";\n")
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write strings: %w", err)
+	}
+
+	return nil
+}
+
+// lexer tokenizes the old-style property list notation of .strings files
+type lexer struct {
+	src     []rune
+	pos     int
+	line    int
+	comment string
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", l.line, fmt.Sprintf(format, args...))
+}
+
+// skip consumes whitespace and comments and remembers the last comment
+func (l *lexer) skip() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+		case c == '/' && l.peek(1) == '*':
+			start := l.pos + 2
+			l.pos = start
+
+			for l.pos < len(l.src) && !(l.src[l.pos] == '*' && l.peek(1) == '/') {
+				if l.src[l.pos] == '\n' {
+					l.line++
+				}
+
+				l.pos++
+			}
+
+			l.comment = strings.TrimSpace(string(l.src[start:l.pos]))
+			l.pos += 2
+		case c == '/' && l.peek(1) == '/':
+			start := l.pos + 2
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+
+			l.comment = strings.TrimSpace(string(l.src[start:l.pos]))
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+
+	return 0
+}
+
+// next returns the next non-whitespace rune or 0
+func (l *lexer) next() rune {
+	l.skip()
+
+	if l.pos >= len(l.src) {
+		return 0
+	}
+
+	l.pos++
+
+	return l.src[l.pos-1]
+}
+
+// token returns the next quoted or unquoted string. Returns false at the end of the input.
+func (l *lexer) token() (string, bool, error) {
+	l.skip()
+
+	if l.pos >= len(l.src) {
+		return "", false, nil
+	}
+
+	if l.src[l.pos] != '"' {
+		start := l.pos
+		for l.pos < len(l.src) && isUnquoted(l.src[l.pos]) {
+			l.pos++
+		}
+
+		if start == l.pos {
+			return "", false, l.errorf("unexpected '%c'", l.src[l.pos])
+		}
+
+		return string(l.src[start:l.pos]), true, nil
+	}
+
+	l.pos++
+	sb := &strings.Builder{}
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		l.pos++
+
+		switch c {
+		case '"':
+			return sb.String(), true, nil
+		case '\n':
+			l.line++
+			sb.WriteRune(c)
+		case '\\':
+			if err := l.escape(sb); err != nil {
+				return "", false, err
+			}
+		default:
+			sb.WriteRune(c)
+		}
+	}
+
+	return "", false, l.errorf("unterminated string")
+}
+
+// escape decodes the escape sequence after a backslash
+func (l *lexer) escape(sb *strings.Builder) error {
+	if l.pos >= len(l.src) {
+		return l.errorf("unterminated escape sequence")
+	}
+
+	c := l.src[l.pos]
+	l.pos++
+
+	switch c {
+	case 'n':
+		sb.WriteByte('\n')
+	case 't':
+		sb.WriteByte('\t')
+	case 'r':
+		sb.WriteByte('\r')
+	case 'U', 'u':
+		if l.pos+4 > len(l.src) {
+			return l.errorf("invalid unicode escape sequence")
+		}
+
+		r, err := strconv.ParseUint(string(l.src[l.pos:l.pos+4]), 16, 32)
+		if err != nil {
+			return l.errorf("invalid unicode escape sequence: %v", err)
+		}
+
+		sb.WriteRune(rune(r))
+		l.pos += 4
+	default:
+		sb.WriteRune(c)
+	}
+
+	return nil
+}
+
+func isUnquoted(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("_.$:/-", c)
+}
+
+// quote is the inverse of the lexers string token
+func quote(str string) string {
+	return `"` + quoteEscaper.Replace(str) + `"`
+}
+
+// nolint: gochecknoglobals
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
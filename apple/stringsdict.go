@@ -0,0 +1,339 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apple
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	keyFormat     = "NSStringLocalizedFormatKey"
+	keySpecType   = "NSStringFormatSpecTypeKey"
+	keyValueType  = "NSStringFormatValueTypeKey"
+	pluralRule    = "NSStringPluralRuleType"
+	defaultVarKey = "value"
+)
+
+// Categories are the CLDR plural categories in their natural order, as used as keys by a plural rule dictionary.
+// nolint: gochecknoglobals
+var Categories = []string{"zero", "one", "two", "few", "many", "other"}
+
+// Plural is a single entry of a .stringsdict file, whose NSStringLocalizedFormatKey contains exactly one
+// NSStringPluralRuleType variable, like "%#@cats@".
+type Plural struct {
+	Key string
+	// Format is the NSStringLocalizedFormatKey, like "%#@cats@" or "found %#@cats@"
+	Format string
+	// Variable is the name of the plural variable, like cats
+	Variable string
+	// ValueType is the NSStringFormatValueTypeKey, like d
+	ValueType string
+	// Forms contains the texts by their CLDR category
+	Forms map[string]string
+}
+
+// Texts returns the formats, where the variable has been substituted by the according category text.
+func (p Plural) Texts() map[string]string {
+	res := make(map[string]string, len(p.Forms))
+	for category, text := range p.Forms {
+		res[category] = strings.ReplaceAll(p.Format, "%#@"+p.Variable+"@", text)
+	}
+
+	return res
+}
+
+// ReadStringsDict parses a .stringsdict property list. Each entry must declare a plural rule variable. If a
+// format contains more than one variable, the others are replaced by their other form.
+func ReadStringsDict(reader io.Reader) ([]Plural, error) {
+	root, err := readPlist(xml.NewDecoder(reader))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plist: %w", err)
+	}
+
+	dict, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected root dict")
+	}
+
+	keys := make([]string, 0, len(dict))
+	for k := range dict {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	res := make([]Plural, 0, len(keys))
+
+	for _, key := range keys {
+		entry, ok := dict[key].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected dict for '%s'", key)
+		}
+
+		pl, err := parsePlural(key, entry)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, pl)
+	}
+
+	return res, nil
+}
+
+func parsePlural(key string, entry map[string]interface{}) (Plural, error) {
+	format, _ := entry[keyFormat].(string)
+	pl := Plural{Key: key, Format: format}
+
+	for _, name := range variables(format) {
+		rule, ok := entry[name].(map[string]interface{})
+		if !ok || rule[keySpecType] != pluralRule {
+			return pl, fmt.Errorf("'%s' declares the variable '%s' without a plural rule", key, name)
+		}
+
+		if pl.Variable != "" {
+			other, _ := rule["other"].(string)
+			pl.Format = strings.ReplaceAll(pl.Format, "%#@"+name+"@", other)
+
+			continue
+		}
+
+		pl.Variable = name
+		pl.ValueType, _ = rule[keyValueType].(string)
+		pl.Forms = make(map[string]string)
+
+		for _, category := range Categories {
+			if text, ok := rule[category].(string); ok {
+				pl.Forms[category] = text
+			}
+		}
+	}
+
+	if pl.Variable == "" {
+		return pl, fmt.Errorf("'%s' has no plural variable in '%s'", key, format)
+	}
+
+	return pl, nil
+}
+
+// variables returns the names of all %#@name@ variables in order of appearance
+func variables(format string) []string {
+	var res []string
+
+	for {
+		b := strings.Index(format, "%#@")
+		if b < 0 {
+			return res
+		}
+
+		e := strings.IndexByte(format[b+3:], '@')
+		if e < 0 {
+			return res
+		}
+
+		res = append(res, format[b+3:b+3+e])
+		format = format[b+3+e+1:]
+	}
+}
+
+// ReadStringsDictFile parses a .stringsdict file from the file system
+func ReadStringsDictFile(fname string) ([]Plural, error) {
+	file, err := os.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open '%s':%w", fname, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return ReadStringsDict(file)
+}
+
+// WriteStringsDict serializes the plurals as .stringsdict property list, sorted by key. An empty format or variable
+// defaults to "%#@value@".
+func WriteStringsDict(writer io.Writer, plurals []Plural) error {
+	tmp := append([]Plural(nil), plurals...)
+	sort.SliceStable(tmp, func(i, j int) bool {
+		return tmp[i].Key < tmp[j].Key
+	})
+
+	w := bufio.NewWriter(writer)
+	_, _ = w.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` +
+		"\n<plist version=\"1.0\">\n<dict>\n")
+
+	for _, pl := range tmp {
+		variable := pl.Variable
+		if variable == "" {
+			variable = defaultVarKey
+		}
+
+		format := pl.Format
+		if format == "" {
+			format = "%#@" + variable + "@"
+		}
+
+		valueType := pl.ValueType
+		if valueType == "" {
+			valueType = "d"
+		}
+
+		writeKV(w, 1, pl.Key, "")
+		_, _ = w.WriteString("\t<dict>\n")
+		writeKV(w, 2, keyFormat, format)
+		writeKV(w, 2, variable, "")
+		_, _ = w.WriteString("\t\t<dict>\n")
+		writeKV(w, 3, keySpecType, pluralRule)
+		writeKV(w, 3, keyValueType, valueType)
+
+		for _, category := range Categories {
+			if text, ok := pl.Forms[category]; ok {
+				writeKV(w, 3, category, text)
+			}
+		}
+
+		_, _ = w.WriteString("\t\t</dict>\n\t</dict>\n")
+	}
+
+	_, _ = w.WriteString("</dict>\n</plist>\n")
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write stringsdict: %w", err)
+	}
+
+	return nil
+}
+
+// writeKV writes a key and, if not empty, a string value
+func writeKV(w *bufio.Writer, depth int, key, value string) {
+	indent := strings.Repeat("\t", depth)
+	_, _ = w.WriteString(indent + "<key>" + escapeXML(key) + "</key>\n")
+
+	if value != "" {
+		_, _ = w.WriteString(indent + "<string>" + escapeXML(value) + "</string>\n")
+	}
+}
+
+func escapeXML(str string) string {
+	sb := &strings.Builder{}
+	_ = xml.EscapeText(sb, []byte(str))
+
+	return sb.String()
+}
+
+// readPlist reads the next plist value, which is either a dict as map, an array as slice or anything else as string.
+func readPlist(dec *xml.Decoder) (interface{}, error) {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "plist":
+			continue
+		case "dict":
+			return readDict(dec)
+		case "array":
+			return readArray(dec)
+		case "true", "false":
+			return start.Name.Local, dec.Skip()
+		default:
+			var str string
+			err := dec.DecodeElement(&str, &start)
+
+			return str, err
+		}
+	}
+}
+
+func readDict(dec *xml.Decoder) (map[string]interface{}, error) {
+	res := make(map[string]interface{})
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.EndElement:
+			return res, nil
+		case xml.StartElement:
+			if t.Name.Local != "key" {
+				return nil, fmt.Errorf("expected key but got %s", t.Name.Local)
+			}
+
+			var key string
+			if err := dec.DecodeElement(&key, &t); err != nil {
+				return nil, err
+			}
+
+			value, err := readPlist(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			res[key] = value
+		}
+	}
+}
+
+func readArray(dec *xml.Decoder) ([]interface{}, error) {
+	var res []interface{}
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.EndElement:
+			return res, nil
+		case xml.StartElement:
+			var value interface{}
+
+			switch t.Name.Local {
+			case "dict":
+				value, err = readDict(dec)
+			case "array":
+				value, err = readArray(dec)
+			default:
+				var str string
+				err = dec.DecodeElement(&str, &t)
+				value = str
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, value)
+		}
+	}
+}
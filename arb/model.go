@@ -0,0 +1,234 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// KeyLocale is the global attribute which declares the locale of a file
+	KeyLocale = "@@locale"
+
+	// TypeString is the placeholder type for texts
+	TypeString = "String"
+	// TypeInt is the placeholder type for integers
+	TypeInt = "int"
+	// TypeDouble is the placeholder type for floating point numbers
+	TypeDouble = "double"
+	// TypeNum is the placeholder type for any number
+	TypeNum = "num"
+)
+
+// File is an Application Resource Bundle, as used by flutter. The messages and placeholders keep the order of
+// their declaration.
+type File struct {
+	Locale     string
+	Attributes map[string]string // Attributes are the other global @@ attributes, like @@last_modified
+	Messages   []Message
+}
+
+// Message is a key with an ICU MessageFormat value and the optional metadata of its @key attribute.
+type Message struct {
+	Key          string
+	Value        string
+	Description  string
+	Type         string // Type is the optional message type, like text
+	Placeholders []Placeholder
+}
+
+// Placeholder declares a named argument of a message
+type Placeholder struct {
+	Name    string
+	Type    string // Type is one of String, int, double, num, DateTime or Object. It is empty if undeclared.
+	Format  string
+	Example string
+}
+
+// Get returns the message with the given key
+func (f File) Get(key string) (Message, bool) {
+	for _, msg := range f.Messages {
+		if msg.Key == key {
+			return msg, true
+		}
+	}
+
+	return Message{}, false
+}
+
+// Read parses an ARB json document. Metadata attributes without a message are ignored.
+func Read(reader io.Reader) (File, error) {
+	res := File{Attributes: make(map[string]string)}
+	dec := json.NewDecoder(reader)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return res, err
+	}
+
+	meta := make(map[string]json.RawMessage)
+
+	for dec.More() {
+		key, err := stringToken(dec)
+		if err != nil {
+			return res, err
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return res, fmt.Errorf("invalid value of '%s': %w", key, err)
+		}
+
+		switch {
+		case strings.HasPrefix(key, "@@"):
+			var str string
+			if err := json.Unmarshal(raw, &str); err != nil {
+				str = string(raw)
+			}
+
+			if key == KeyLocale {
+				res.Locale = str
+			} else {
+				res.Attributes[key] = str
+			}
+		case strings.HasPrefix(key, "@"):
+			meta[key[1:]] = raw
+		default:
+			var str string
+			if err := json.Unmarshal(raw, &str); err != nil {
+				return res, fmt.Errorf("expected string value of '%s': %w", key, err)
+			}
+
+			res.Messages = append(res.Messages, Message{Key: key, Value: str})
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return res, err
+	}
+
+	for i, msg := range res.Messages {
+		raw, ok := meta[msg.Key]
+		if !ok {
+			continue
+		}
+
+		if err := parseMeta(raw, &res.Messages[i]); err != nil {
+			return res, fmt.Errorf("invalid attributes of '%s': %w", msg.Key, err)
+		}
+	}
+
+	return res, nil
+}
+
+// ReadFile parses an ARB file from the file system
+func ReadFile(fname string) (File, error) {
+	file, err := os.Open(fname)
+	if err != nil {
+		return File{}, fmt.Errorf("cannot open '%s':%w", fname, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return Read(file)
+}
+
+// parseMeta reads the @key attributes, keeping the declaration order of the placeholders
+func parseMeta(raw json.RawMessage, msg *Message) error {
+	var attrs struct {
+		Description  string          `json:"description"`
+		Type         string          `json:"type"`
+		Placeholders json.RawMessage `json:"placeholders"`
+	}
+
+	if err := json.Unmarshal(raw, &attrs); err != nil {
+		return err
+	}
+
+	msg.Description = attrs.Description
+	msg.Type = attrs.Type
+
+	if len(attrs.Placeholders) == 0 {
+		return nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(attrs.Placeholders)))
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		name, err := stringToken(dec)
+		if err != nil {
+			return err
+		}
+
+		var ph struct {
+			Type    string      `json:"type"`
+			Format  string      `json:"format"`
+			Example interface{} `json:"example"`
+		}
+
+		if err := dec.Decode(&ph); err != nil {
+			return fmt.Errorf("invalid placeholder '%s': %w", name, err)
+		}
+
+		example := ""
+		if ph.Example != nil {
+			example = fmt.Sprint(ph.Example)
+		}
+
+		msg.Placeholders = append(msg.Placeholders, Placeholder{
+			Name:    name,
+			Type:    ph.Type,
+			Format:  ph.Format,
+			Example: example,
+		})
+	}
+
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("expected '%v': %w", delim, err)
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expected '%v' but got '%v'", delim, tok)
+	}
+
+	return nil
+}
+
+func stringToken(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", fmt.Errorf("expected key: %w", err)
+	}
+
+	str, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("expected key but got '%v'", tok)
+	}
+
+	return str, nil
+}
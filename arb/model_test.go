@@ -0,0 +1,68 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arb
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	file, err := ReadFile("app_test.arb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file.Locale != "de" || file.Attributes["@@last_modified"] != "2020-10-01T12:00:00Z" {
+		t.Fatalf("unexpected attributes %+v", file)
+	}
+
+	if len(file.Messages) != 5 {
+		t.Fatalf("expected 5 messages but got %+v", file.Messages)
+	}
+
+	msg, ok := file.Get("app_name")
+	if !ok || msg.Value != "LeichteApp" || msg.Description != "The name of the app" {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+
+	msg, _ = file.Get("x_runs_around_y")
+	expected := []Placeholder{
+		{Name: "thing", Type: TypeString, Example: "Baum"},
+		{Name: "name", Type: TypeString},
+		{Name: "times", Type: TypeInt, Example: "3"},
+	}
+
+	if !reflect.DeepEqual(msg.Placeholders, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, msg.Placeholders)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tests := []string{
+		``,
+		`[]`,
+		`{"a": 1}`,
+		`{"a": "b", "@a": "c"}`,
+		`{"a": "b"`,
+	}
+
+	for _, tt := range tests {
+		if _, err := Read(strings.NewReader(tt)); err == nil {
+			t.Fatalf("expected error for '%s'", tt)
+		}
+	}
+}
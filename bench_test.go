@@ -0,0 +1,212 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"golang.org/x/text/language"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// The benchmarks compare the lock-free snapshots with the former RWMutex design, see also make bench:
+//   go test -run=^$ -bench=. -cpu=1,8,32 .
+
+// rwMutexResources is the former read path of Resources
+type rwMutexResources struct {
+	values map[string]Value
+	mutex  sync.RWMutex
+}
+
+func (l *rwMutexResources) Text(id string, args ...interface{}) (string, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	value := l.values[id]
+	if value == nil {
+		return "", ErrTextNotFound
+	}
+
+	return value.Text(args...)
+}
+
+// rwMutexLocalizations is the former read path of localizations
+type rwMutexLocalizations struct {
+	translationPriority []language.Tag
+	translations        map[language.Tag]*rwMutexResources
+	translationsMutex   sync.RWMutex
+	matcher             language.Matcher
+	parsedTags          map[string]language.Tag
+	parsedTagsMutex     sync.RWMutex
+}
+
+func (l *rwMutexLocalizations) Locale(locale string) language.Tag {
+	l.parsedTagsMutex.RLock()
+	tag, exists := l.parsedTags[locale]
+	l.parsedTagsMutex.RUnlock()
+
+	if exists {
+		return tag
+	}
+
+	l.parsedTagsMutex.Lock()
+	defer l.parsedTagsMutex.Unlock()
+
+	res := language.Make(locale)
+	l.parsedTags[locale] = res
+
+	return res
+}
+
+func (l *rwMutexLocalizations) Match(locales ...string) *rwMutexResources {
+	l.translationsMutex.RLock()
+	defer l.translationsMutex.RUnlock()
+
+	tmp := make([]language.Tag, 0, len(locales))
+	for _, locale := range locales {
+		tmp = append(tmp, l.Locale(locale))
+	}
+
+	_, index, _ := l.matcher.Match(tmp...)
+
+	return l.translations[l.translationPriority[index]]
+}
+
+func benchLocales() []string {
+	return []string{"und", "en", "de", "de-AT", "fr", "it", "es", "ja"}
+}
+
+func newBenchBundle() *Bundle {
+	bundle := NewBundle()
+	for _, locale := range benchLocales() {
+		for i := 0; i < 100; i++ {
+			bundle.ImportValue(NewText(locale, "key_"+strconv.Itoa(i), locale+" %s"))
+		}
+	}
+
+	return bundle
+}
+
+func newBenchRWMutexLocalizations() *rwMutexLocalizations {
+	l := &rwMutexLocalizations{
+		translations: make(map[language.Tag]*rwMutexResources),
+		parsedTags:   make(map[string]language.Tag),
+	}
+
+	for _, locale := range benchLocales() {
+		res := &rwMutexResources{values: make(map[string]Value)}
+		for i := 0; i < 100; i++ {
+			res.values["key_"+strconv.Itoa(i)] = NewText(locale, "key_"+strconv.Itoa(i), locale+" %s")
+		}
+
+		tag := l.Locale(locale)
+		l.translations[tag] = res
+		l.translationPriority = append(l.translationPriority, tag)
+	}
+
+	l.matcher = language.NewMatcher(l.translationPriority)
+
+	return l
+}
+
+func BenchmarkText(b *testing.B) {
+	b.Run("snapshot", func(b *testing.B) {
+		res := newBenchBundle().From("de")
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := res.Text("key_42", "x"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+
+	b.Run("rwmutex", func(b *testing.B) {
+		res := newBenchRWMutexLocalizations().Match("de")
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := res.Text("key_42", "x"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+}
+
+func BenchmarkFrom(b *testing.B) {
+	b.Run("snapshot", func(b *testing.B) {
+		bundle := newBenchBundle()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if res := bundle.From("de-CH", "en"); res == nil {
+					b.Fatal("expected resources")
+				}
+			}
+		})
+	})
+
+	b.Run("rwmutex", func(b *testing.B) {
+		l := newBenchRWMutexLocalizations()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if res := l.Match("de-CH", "en"); res == nil {
+					b.Fatal("expected resources")
+				}
+			}
+		})
+	})
+}
+
+func BenchmarkFormat(b *testing.B) {
+	bundle := NewBundle()
+	bundle.ImportValue(NewText("en", "greeting", "hello %s, you have %d new messages"))
+	res := bundle.From("en")
+
+	b.Run("sprintf", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_ = fmt.Sprintf("hello %s, you have %d new messages", "Nick", 42)
+		}
+	})
+
+	b.Run("text", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := res.Text("greeting", "Nick", 42); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("append", func(b *testing.B) {
+		b.ReportAllocs()
+
+		var buf []byte
+
+		for i := 0; i < b.N; i++ {
+			var err error
+			if buf, err = res.AppendText(buf[:0], "greeting", "Nick", 42); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,257 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/log"
+	"github.com/golangee/log/ecs"
+	"golang.org/x/text/language"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// A Bundle is an independent set of localizations, e.g. for a tenant or an isolated test. The package level
+// functions work on a default bundle. All methods are safe for concurrent usage.
+type Bundle struct {
+	res *localizations
+}
+
+// NewBundle creates a new empty bundle.
+func NewBundle() *Bundle {
+	return &Bundle{res: newLocalizations()}
+}
+
+// Import takes the importer and locale and updates the according internal localization resources.
+// The order of import is relevant, because it determines the fallback matching logic. Import your default fallback
+// language first.
+func (b *Bundle) Import(importer Importer, locale string, src io.Reader) error {
+	res := b.res.Configure(locale)
+	err := importer.Import(res, src)
+
+	if err != nil {
+		return fmt.Errorf("failed to parse: %w", err)
+	}
+
+	return nil
+}
+
+// ImportFile is a convenience method for Import. It detects the locale from the file name
+func (b *Bundle) ImportFile(importer Importer, fname string) error {
+	file, err := os.Open(fname)
+	if err != nil {
+		return fmt.Errorf("cannot open file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return b.Import(importer, guessLocaleFromFilename(fname), file)
+}
+
+// ImportFS walks the given file system, e.g. an embed.FS, and imports each file whose path or, if the pattern has no
+// directory, whose name matches the pattern, like "strings*.xml". The locale of each file is detected from its name.
+// Files of the undefined default locale are imported first and all others in the lexical order of their paths, so
+// that the import order and therefore the fallback matching logic is stable.
+func (b *Bundle) ImportFS(importer Importer, fsys fs.FS, pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+	}
+
+	var fnames []string
+
+	err := fs.WalkDir(fsys, ".", func(fname string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		name := fname
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(fname)
+		}
+
+		if ok, _ := path.Match(pattern, name); ok {
+			fnames = append(fnames, fname)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("cannot walk file system: %w", err)
+	}
+
+	sort.SliceStable(fnames, func(i, j int) bool {
+		iUnd := b.res.Locale(guessLocaleFromFilename(path.Base(fnames[i]))) == language.Und
+		jUnd := b.res.Locale(guessLocaleFromFilename(path.Base(fnames[j]))) == language.Und
+
+		if iUnd != jUnd {
+			return iUnd
+		}
+
+		return fnames[i] < fnames[j]
+	})
+
+	for _, fname := range fnames {
+		if err := b.importFSFile(importer, fsys, fname); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bundle) importFSFile(importer Importer, fsys fs.FS, fname string) error {
+	file, err := fsys.Open(fname)
+	if err != nil {
+		return fmt.Errorf("cannot open file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	// an fs.File has no name, but the importers take the origin of the values from it
+	if err := b.Import(importer, guessLocaleFromFilename(path.Base(fname)), namedReader{file, fname}); err != nil {
+		return fmt.Errorf("cannot import '%s': %w", fname, err)
+	}
+
+	return nil
+}
+
+// namedReader provides the Name of a reader, just like an *os.File
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+// Name returns the name of the file
+func (r namedReader) Name() string {
+	return r.name
+}
+
+// Export writes the resources of exactly the given locale using the exporter. It fails if the locale has not
+// been imported.
+func (b *Bundle) Export(exporter Exporter, locale string, dst io.Writer) error {
+	res := b.res.Get(locale)
+	if res == nil {
+		return fmt.Errorf("locale '%s': %w", locale, ErrLocaleNotFound)
+	}
+
+	err := exporter.Export(res, dst)
+	if err != nil {
+		return fmt.Errorf("failed to export: %w", err)
+	}
+
+	return nil
+}
+
+// ImportValue adds or replaces any existing value
+func (b *Bundle) ImportValue(value Value) {
+	b.ImportValues(value)
+}
+
+// ImportValues adds or replaces any existing values. Each affected locale publishes its modifications at once,
+// which is much cheaper than importing each value separately.
+func (b *Bundle) ImportValues(values ...Value) {
+	var res *Resources
+
+	for _, value := range values {
+		tag := b.res.Locale(value.Locale())
+		if res == nil || res.tag != tag {
+			if res != nil {
+				res.unlock()
+			}
+
+			res = b.res.Configure(value.Locale())
+			res.lock()
+		}
+
+		value = value.updateTag(res.tag)
+		if _, has := res.values[value.ID()]; has {
+			logger.Println(ecs.Warn(), ecs.Msg("replacing already translated value"), log.V("key", value.ID()))
+		}
+		res.values[value.ID()] = value
+	}
+
+	if res != nil {
+		res.unlock()
+	}
+}
+
+// From returns the best matching text Resources to the given set of matching locales
+func (b *Bundle) From(locales ...string) *Resources {
+	return b.res.Match(locales...)
+}
+
+// Match returns the best matching text Resources to the given set of matching locales and how sure the matcher is
+// about it. If nothing matches, the resources with the highest translation priority are returned with language.No.
+func (b *Bundle) Match(locales ...string) (*Resources, language.Confidence) {
+	return b.res.MatchConfidence(locales...)
+}
+
+// MatchStrict is like Match but returns ErrNoMatch, if the confidence of the best match is below the given
+// minimum or if nothing has been imported yet.
+func (b *Bundle) MatchStrict(minimum language.Confidence, locales ...string) (*Resources, error) {
+	if len(b.Locales()) == 0 {
+		return nil, fmt.Errorf("no locales imported: %w", ErrNoMatch)
+	}
+
+	res, confidence := b.res.MatchConfidence(locales...)
+	if confidence < minimum {
+		return nil, fmt.Errorf("best match '%s' has confidence %v: %w", res.tag, confidence, ErrNoMatch)
+	}
+
+	return res, nil
+}
+
+// Validate checks the current state of the bundle to see if everything is fine. If no error is returned,
+// you can be sure that at least every key is translated in every language and the printf directives are consistent
+// with each other.
+func (b *Bundle) Validate() error {
+	return validate(b.res.Resources())
+}
+
+// TranslationPriority updates the resolution order and removes unwanted translations. "und" is the undefined default
+// locale.
+func (b *Bundle) TranslationPriority(locales ...string) {
+	b.res.SetTranslationPriority(locales)
+}
+
+// SetFallbackChain updates the chain, which is searched if a key is missing in the matched locale. The default is
+// the DefaultFallbackChain and nil disables the fallback.
+func (b *Bundle) SetFallbackChain(chain FallbackChain) {
+	b.res.SetFallbackChain(chain)
+}
+
+// Locales returns all translated locales
+func (b *Bundle) Locales() []string {
+	var res []string
+	for _, r := range b.res.Resources() {
+		res = append(res, r.tag.String())
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBundle(t *testing.T) {
+	setup()
+
+	tenantA := NewBundle()
+	tenantA.ImportValue(NewText("und", "hello", "Hello A"))
+
+	tenantB := NewBundle()
+	tenantB.ImportValue(NewText("und", "hello", "Hello B"))
+	tenantB.ImportValue(NewText("de", "hello", "Hallo B"))
+
+	tests := []struct {
+		bundle *Bundle
+		locale string
+		want   string
+	}{
+		{tenantA, "de", "Hello A"},
+		{tenantB, "und", "Hello B"},
+		{tenantB, "de", "Hallo B"},
+	}
+
+	for _, tt := range tests {
+		str, err := tt.bundle.From(tt.locale).Text("hello")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if str != tt.want {
+			t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+		}
+	}
+
+	if expected := []string{"de", "und"}; !reflect.DeepEqual(tenantB.Locales(), expected) {
+		t.Fatalf("expected %v but got %v", expected, tenantB.Locales())
+	}
+
+	if len(Locales()) != 0 {
+		t.Fatalf("expected an untouched default bundle but got %v", Locales())
+	}
+}
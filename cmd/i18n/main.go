@@ -0,0 +1,400 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The i18n command generates the accessors of the strings*.xml files of a module, validates them, converts them
+// from and into other formats and reports their completeness and unused keys. Run i18n help for the usage.
+package main
+
+// nolint: goimports // the linter is broken
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/golangee/i18n"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+const usage = `usage: i18n <command> [flags]
+
+The commands are
+  generate  writes the accessors of the strings*.xml files of each package
+  check     validates the strings*.xml files without writing anything
+  export    writes a locale of the strings*.xml files in another format
+  import    converts a file of another format into the android strings xml format
+  stats     prints the completeness of each locale
+  unused    prints the keys, which are not used by any go file or template
+
+Use i18n <command> -h for the flags of a command.
+`
+
+// errUsage indicates invalid arguments, which are already reported by the flag set
+var errUsage = errors.New("invalid usage")
+
+// nolint: gochecknoglobals
+var exporters = map[string]func(bundle *i18n.Bundle) i18n.Exporter{
+	"android": func(*i18n.Bundle) i18n.Exporter { return i18n.AndroidExporter{} },
+	"po": func(bundle *i18n.Bundle) i18n.Exporter {
+		return i18n.POExporter{Source: source(bundle)}
+	},
+	"pot": func(bundle *i18n.Bundle) i18n.Exporter {
+		return i18n.POExporter{Source: source(bundle), Template: true}
+	},
+	"strings":     func(*i18n.Bundle) i18n.Exporter { return i18n.AppleStringsExporter{} },
+	"stringsdict": func(*i18n.Bundle) i18n.Exporter { return i18n.AppleStringsDictExporter{} },
+}
+
+// nolint: gochecknoglobals
+var importers = map[string]i18n.Importer{
+	"android":     i18n.AndroidImporter{},
+	"arb":         i18n.ARBImporter{},
+	"po":          i18n.POImporter{},
+	"strings":     i18n.AppleStringsImporter{},
+	"stringsdict": i18n.AppleStringsDictImporter{},
+	"xliff":       i18n.XLIFFImporter{},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns the exit code, which is 2 for an invalid usage
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = io.WriteString(stderr, usage)
+		return 2
+	}
+
+	var err error
+
+	switch args[0] {
+	case "generate":
+		err = generate(args[1:], stderr)
+	case "check":
+		err = check(args[1:], stderr)
+	case "export":
+		err = export(args[1:], stdout, stderr)
+	case "import":
+		err = convert(args[1:], stdout, stderr)
+	case "stats":
+		err = stats(args[1:], stdout, stderr)
+	case "unused":
+		err = unused(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		_, _ = io.WriteString(stdout, usage)
+		return 0
+	default:
+		_, _ = fmt.Fprintf(stderr, "unknown command '%s'\n\n%s", args[0], usage)
+		return 2
+	}
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	default:
+		_, _ = fmt.Fprintln(stderr, strings.TrimSuffix(err.Error(), "\n"))
+		return 1
+	}
+}
+
+// globs is a repeatable flag, which also accepts comma separated patterns
+type globs []string
+
+func (g *globs) String() string {
+	return strings.Join(*g, ",")
+}
+
+func (g *globs) Set(value string) error {
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			*g = append(*g, pattern)
+		}
+	}
+
+	return nil
+}
+
+// command is the flag set of a command with the common flags, which select the strings files of the module
+type command struct {
+	flags   *flag.FlagSet
+	root    string
+	include globs
+	exclude globs
+}
+
+func newCommand(name string, stderr io.Writer) *command {
+	cmd := &command{flags: flag.NewFlagSet(name, flag.ContinueOnError)}
+	cmd.flags.SetOutput(stderr)
+	cmd.flags.StringVar(&cmd.root, "root", "", "the module root, the module of the working directory by default")
+	cmd.flags.Var(&cmd.include, "include", "glob patterns of the strings files, their names or directories to use")
+	cmd.flags.Var(&cmd.exclude, "exclude", "glob patterns of the strings files, their names or directories to ignore")
+
+	return cmd
+}
+
+// parse parses the flags and reports unexpected positional arguments
+func (c *command) parse(args []string, positional int) error {
+	if err := c.flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return errUsage
+	}
+
+	if c.flags.NArg() != positional {
+		_, _ = fmt.Fprintf(c.flags.Output(), "%s expects %d arguments but got %d\n", c.flags.Name(), positional,
+			c.flags.NArg())
+		c.flags.Usage()
+
+		return errUsage
+	}
+
+	return nil
+}
+
+func (c *command) options() i18n.ModuleOptions {
+	return i18n.ModuleOptions{Dir: c.root, Include: c.include, Exclude: c.exclude}
+}
+
+func generate(args []string, stderr io.Writer) error {
+	cmd := newCommand("generate", stderr)
+	output := cmd.flags.String("output", "strings_gen.go", "the name of the generated file in each package")
+
+	if err := cmd.parse(args, 0); err != nil {
+		return err
+	}
+
+	opts := cmd.options()
+	opts.Output = *output
+
+	return i18n.GenerateWith(opts)
+}
+
+func check(args []string, stderr io.Writer) error {
+	cmd := newCommand("check", stderr)
+	if err := cmd.parse(args, 0); err != nil {
+		return err
+	}
+
+	t, err := i18n.Scan(cmd.options())
+	if err != nil {
+		return err
+	}
+
+	return t.Validate()
+}
+
+func export(args []string, stdout, stderr io.Writer) error {
+	cmd := newCommand("export", stderr)
+	format := cmd.flags.String("format", "android", "the exported format, one of "+names(exporters))
+	locale := cmd.flags.String("locale", "und", "the exported locale")
+	output := cmd.flags.String("o", "", "the exported file, stdout by default")
+
+	if err := cmd.parse(args, 0); err != nil {
+		return err
+	}
+
+	exporter, ok := exporters[*format]
+	if !ok {
+		return fmt.Errorf("unknown format '%s', expected one of %s", *format, names(exporters))
+	}
+
+	t, err := i18n.Scan(cmd.options())
+	if err != nil {
+		return err
+	}
+
+	bundle := t.Bundle()
+
+	return write(*output, stdout, func(dst io.Writer) error {
+		return bundle.Export(exporter(bundle), *locale, dst)
+	})
+}
+
+// convert implements the import command, which is a reserved word
+func convert(args []string, stdout, stderr io.Writer) error {
+	cmd := &command{flags: flag.NewFlagSet("import", flag.ContinueOnError)}
+	cmd.flags.SetOutput(stderr)
+	format := cmd.flags.String("format", "xliff", "the imported format, one of "+names(importers))
+	locale := cmd.flags.String("locale", "", "the imported locale, guessed from the file name by default")
+	output := cmd.flags.String("o", "", "the android strings xml file, stdout by default")
+
+	if err := cmd.parse(args, 1); err != nil {
+		return err
+	}
+
+	importer, ok := importers[*format]
+	if !ok {
+		return fmt.Errorf("unknown format '%s', expected one of %s", *format, names(importers))
+	}
+
+	bundle := i18n.NewBundle()
+	fname := cmd.flags.Arg(0)
+
+	if *locale == "" {
+		if err := bundle.ImportFile(importer, fname); err != nil {
+			return fmt.Errorf("cannot import '%s': %w", fname, err)
+		}
+
+		locales := bundle.Locales()
+		if len(locales) != 1 {
+			return fmt.Errorf("cannot guess the locale of '%s', use -locale", fname)
+		}
+
+		*locale = locales[0]
+	} else {
+		file, err := os.Open(fname)
+		if err != nil {
+			return fmt.Errorf("cannot open file: %w", err)
+		}
+
+		err = bundle.Import(importer, *locale, file)
+		_ = file.Close()
+
+		if err != nil {
+			return fmt.Errorf("cannot import '%s': %w", fname, err)
+		}
+	}
+
+	return write(*output, stdout, func(dst io.Writer) error {
+		return bundle.Export(i18n.AndroidExporter{}, *locale, dst)
+	})
+}
+
+func stats(args []string, stdout, stderr io.Writer) error {
+	cmd := newCommand("stats", stderr)
+	if err := cmd.parse(args, 0); err != nil {
+		return err
+	}
+
+	t, err := i18n.Scan(cmd.options())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(w, "locale\ttranslated\ttotal\tcomplete\t")
+
+	for _, s := range t.Stats() {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\t\n", s.Locale, s.Translated, s.Total, s.Percent())
+	}
+
+	return w.Flush()
+}
+
+func unused(args []string, stdout, stderr io.Writer) error {
+	cmd := newCommand("unused", stderr)
+	remove := cmd.flags.Bool("remove", false, "removes the unused keys from the strings files")
+
+	if err := cmd.parse(args, 0); err != nil {
+		return err
+	}
+
+	t, err := i18n.Scan(cmd.options())
+	if err != nil {
+		return err
+	}
+
+	packages, err := t.Unused()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+
+	for _, pkg := range packages {
+		for _, key := range pkg.Keys {
+			_, _ = fmt.Fprintf(stdout, "%s: %s\n", pkg.Dir, key)
+		}
+
+		count += len(pkg.Keys)
+
+		if *remove {
+			if err := pkg.Remove(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if count > 0 && !*remove {
+		return fmt.Errorf("found %d unused keys", count)
+	}
+
+	return nil
+}
+
+// write writes into the file or into stdout, if the name is empty. The file is removed again, if f fails, so that
+// no incomplete file is left behind.
+func write(fname string, stdout io.Writer, f func(dst io.Writer) error) error {
+	if fname == "" {
+		return f(stdout)
+	}
+
+	file, err := os.Create(fname)
+	if err != nil {
+		return fmt.Errorf("cannot create file: %w", err)
+	}
+
+	if err := f(file); err != nil {
+		_ = file.Close()
+		_ = os.Remove(fname)
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("cannot close file: %w", err)
+	}
+
+	return nil
+}
+
+// names returns the sorted keys of the formats
+func names(formats interface{}) string {
+	var res []string
+
+	switch m := formats.(type) {
+	case map[string]i18n.Importer:
+		for name := range m {
+			res = append(res, name)
+		}
+	case map[string]func(bundle *i18n.Bundle) i18n.Exporter:
+		for name := range m {
+			res = append(res, name)
+		}
+	}
+
+	sort.Strings(res)
+
+	return strings.Join(res, ", ")
+}
+
+// source returns the default locale, which provides the msgid of PO files, or nil
+func source(bundle *i18n.Bundle) *i18n.Resources {
+	for _, locale := range bundle.Locales() {
+		if locale == "und" {
+			return bundle.From(locale)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	files := map[string]string{
+		"main.go": "package main\n\nfunc main() {\n\t_ = NewResources(\"en\").HelloX(\"Nick\")\n}\n",
+		"strings.xml": `<resources>
+    <string name="hello_x">Hello %s</string>
+    <string name="unused">unused</string>
+</resources>
+`,
+		"strings-de.xml": `<resources>
+    <string name="hello_x">Hallo %s</string>
+</resources>
+`,
+		"broken/broken.go":      "package broken\n",
+		"broken/strings.xml":    `<resources><string name="x">%s</string></resources>`,
+		"broken/strings-de.xml": `<resources><string name="x">%d</string></resources>`,
+		"import/strings-fr.arb": `{"@@locale": "fr", "hello_x": "Bonjour {name}",` +
+			`"@hello_x": {"placeholders": {"name": {}}}}`,
+		"import/nolocale.arb":    `{"hello_x": "Bonjour"}`,
+		"export/placeholder.txt": "",
+	}
+
+	for name, content := range files {
+		fname := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fname), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fname, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{"no command", nil, 2, "", "usage: i18n <command>"},
+		{"help", []string{"help"}, 0, "usage: i18n <command>", ""},
+		{"unknown command", []string{"compile"}, 2, "", "unknown command 'compile'"},
+		{"unknown flag", []string{"check", "-x"}, 2, "", "flag provided but not defined: -x"},
+		{"unexpected argument", []string{"check", "-root", dir, "x"}, 2, "", "check expects 0 arguments but got 1"},
+		{"check", []string{"check", "-root", dir}, 1, "", "has at index 0 the verb 'd'"},
+		{"check excluded", []string{"check", "-root", dir, "-exclude", "broken"}, 0, "", ""},
+		{"stats", []string{"stats", "-root", dir, "-exclude", "broken"}, 0, "de           1      2     50.0%", ""},
+		{"unused", []string{"unused", "-root", dir, "-exclude", "broken"}, 1, dir + ": unused", "found 1 unused keys"},
+		{"export", []string{"export", "-root", dir, "-format", "po", "-locale", "de", "-exclude", "broken"}, 0,
+			"msgctxt \"hello_x\"\nmsgid \"Hello %s\"\nmsgstr \"Hallo %s\"", ""},
+		{"export unknown format", []string{"export", "-root", dir, "-format", "csv"}, 1, "", "unknown format 'csv'"},
+		{"export unknown locale", []string{"export", "-root", dir, "-locale", "it"}, 1, "", "locale 'it'"},
+		{"import", []string{"import", "-format", "arb", filepath.Join(dir, "import", "strings-fr.arb")}, 0,
+			`<string name="hello_x">Bonjour %1$s</string>`, ""},
+		{"import locale", []string{"import", "-format", "arb", "-locale", "fr-CA",
+			filepath.Join(dir, "import", "nolocale.arb")}, 0, `<string name="hello_x">Bonjour</string>`, ""},
+		{"import without file", []string{"import", "-format", "arb"}, 2, "", "import expects 1 arguments but got 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if code := run(tt.args, stdout, stderr); code != tt.code {
+				t.Fatalf("expected exit code %d but got %d:\n%s%s", tt.code, code, stdout.String(), stderr.String())
+			}
+
+			if !strings.Contains(stdout.String(), tt.stdout) || !strings.Contains(stderr.String(), tt.stderr) {
+				t.Fatalf("expected '%s' and '%s' but got:\n%s\n%s", tt.stdout, tt.stderr, stdout.String(),
+					stderr.String())
+			}
+		})
+	}
+
+	out := filepath.Join(dir, "export", "strings-de.xml")
+	if code := run([]string{"export", "-root", dir, "-exclude", "broken", "-locale", "de", "-o", out}, os.Stdout,
+		os.Stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+
+	if code := run([]string{"generate", "-root", dir, "-exclude", "broken", "-output", "i18n_gen.go"}, os.Stdout,
+		os.Stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+
+	for _, fname := range []string{out, filepath.Join(dir, "i18n_gen.go")} {
+		if _, err := os.Stat(fname); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if code := run([]string{"unused", "-root", dir, "-exclude", "broken", "-remove"}, os.Stdout, os.Stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d", code)
+	}
+
+	if code := run([]string{"unused", "-root", dir, "-exclude", "broken"}, os.Stdout, os.Stderr); code != 0 {
+		t.Fatalf("expected no more unused keys but got exit code %d", code)
+	}
+}
+
+func TestConvertSelect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cmd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	arb := filepath.Join(dir, "strings.arb")
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(arb, []byte(`{"x_invited_you": "{gender, select, female{Sie} other{Es}} hat dich `+
+		`eingeladen", "items": "{count, plural, =0{keine} other{# Dinge}}"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	xml, po := filepath.Join(dir, "strings.xml"), filepath.Join(dir, "strings.po")
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if code := run([]string{"import", "-format", "arb", "-o", xml, arb}, stdout, stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	if code := run([]string{"export", "-root", dir, "-format", "android"}, stdout, stderr); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+
+	for _, want := range []string{`<string-array name="x_invited_you" tools:select="female,other">`,
+		`<string name="items" tools:format="icu">`} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Fatalf("expected '%s' in:\n%s", want, stdout.String())
+		}
+	}
+
+	if code := run([]string{"export", "-root", dir, "-format", "po", "-o", po}, stdout, stderr); code != 1 {
+		t.Fatalf("expected exit code 1 but got %d", code)
+	}
+
+	if !strings.Contains(stderr.String(), "the po format cannot hold") {
+		t.Fatalf("unexpected error: %s", stderr.String())
+	}
+
+	if _, err := os.Stat(po); !os.IsNotExist(err) {
+		t.Fatalf("expected no incomplete po file but got %v", err)
+	}
+}
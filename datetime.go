@@ -0,0 +1,388 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Style is the length of a localized date or time
+type Style int
+
+const (
+	// NoStyle omits the date or the time
+	NoStyle Style = iota
+	// Short is mostly numeric, like 1/2/06 or 3:04 PM
+	Short
+	// Medium abbreviates the names, like Jan 2, 2006 or 3:04:05 PM
+	Medium
+	// Long is like January 2, 2006 or 3:04:05 PM MST
+	Long
+	// Full is like Monday, January 2, 2006 or 3:04:05 PM MST
+	Full
+)
+
+// A DateTime is a time.Time, which is formatted by the CLDR patterns of the message locale, like "Jan 2, 2006, 3:04 PM"
+// in english or "02.01.2006, 15:04" in german. Without any style or skeleton, the date is Medium and the time Short.
+// Use it with %s or %v. A width pads the text, like %-20v, but a precision is not supported.
+type DateTime struct {
+	Time      time.Time
+	DateStyle Style
+	TimeStyle Style
+
+	// Skeleton takes precedence over the styles and contains the wanted fields, like yMMMd for "Jan 2, 2006" or
+	// "2. Jan. 2006". An unknown skeleton is used as a CLDR pattern, like "EEEE, d MMMM".
+	Skeleton string
+}
+
+// Date returns a DateTime, which only formats the date
+func Date(t time.Time, style Style) DateTime {
+	return DateTime{Time: t, DateStyle: style}
+}
+
+// Time returns a DateTime, which only formats the time
+func Time(t time.Time, style Style) DateTime {
+	return DateTime{Time: t, TimeStyle: style}
+}
+
+// Skeleton returns a DateTime, which formats the fields of the CLDR skeleton, like yMMMd or Hm
+func Skeleton(t time.Time, skeleton string) DateTime {
+	return DateTime{Time: t, Skeleton: skeleton}
+}
+
+// Format implements fmt.Formatter
+func (d DateTime) Format(s fmt.State, verb rune) {
+	pad(s, printerOf(languageOf(s)).dateTime(d))
+}
+
+// A Duration is formatted by its days, hours, minutes and seconds, like "1 day, 2 hours" in english or
+// "1 Tag und 2 Stunden" in german. Use it with %s or %v, a width pads the text.
+type Duration time.Duration
+
+// Format implements fmt.Formatter
+func (d Duration) Format(s fmt.State, verb rune) {
+	pad(s, printerOf(languageOf(s)).duration(time.Duration(d)))
+}
+
+// A RelativeTime is the duration from now to a point in time, which is formatted in its largest unit with the plural
+// form of the message locale, like "3 days ago" or "in 2 hours". Use it with %s or %v, a width pads the text.
+type RelativeTime time.Duration
+
+// RelativeTo returns the relative time of t as seen from now, which is in the past if t is before now
+func RelativeTo(t, now time.Time) RelativeTime {
+	return RelativeTime(t.Sub(now))
+}
+
+// Format implements fmt.Formatter
+func (r RelativeTime) Format(s fmt.State, verb rune) {
+	pad(s, printerOf(languageOf(s)).relative(time.Duration(r)))
+}
+
+// calendar returns the calendar data of the language and the tag, which selects the plural forms of that data
+func (p *numberPrinter) calendar() (*calendarData, language.Tag) {
+	base, _ := p.tag.Base()
+	if c, ok := calendars[base.String()]; ok {
+		return c, p.tag
+	}
+
+	return rootCalendar, language.English
+}
+
+func (p *numberPrinter) dateTime(d DateTime) string {
+	c, _ := p.calendar()
+	if d.Skeleton != "" {
+		return c.format(d.Time, c.skeleton(d.Skeleton))
+	}
+
+	date, clock := clampStyle(d.DateStyle), clampStyle(d.TimeStyle)
+	switch {
+	case date == NoStyle && clock == NoStyle:
+		date, clock = Medium, Short
+	case clock == NoStyle:
+		return c.format(d.Time, c.date[date-1])
+	case date == NoStyle:
+		return c.format(d.Time, c.time[clock-1])
+	}
+
+	// the combination pattern just contains the patterns of date and time
+	pattern := strings.NewReplacer("{0}", c.time[clock-1], "{1}", c.date[date-1]).Replace(c.dateTime[date-1])
+
+	return c.format(d.Time, pattern)
+}
+
+// clampStyle treats unknown styles as the nearest one
+func clampStyle(s Style) Style {
+	switch {
+	case s < NoStyle:
+		return NoStyle
+	case s > Full:
+		return Full
+	default:
+		return s
+	}
+}
+
+// skeleton returns the pattern for the skeleton of the language or the generic one. An unknown skeleton is
+// returned as is.
+func (c *calendarData) skeleton(skeleton string) string {
+	if pattern, ok := c.skeletons[skeleton]; ok {
+		return pattern
+	}
+
+	if pattern, ok := genericSkeletons[skeleton]; ok {
+		return pattern
+	}
+
+	return skeleton
+}
+
+// format interprets the CLDR date pattern, like "EEEE, d. MMMM y". Text in apostrophes is literal and two
+// apostrophes are a single one.
+func (c *calendarData) format(t time.Time, pattern string) string {
+	sb := &strings.Builder{}
+
+	for i := 0; i < len(pattern); {
+		ch := pattern[i]
+
+		switch {
+		case ch == '\'':
+			i = writeQuoted(sb, pattern, i+1)
+		case 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z':
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == ch {
+				n++
+			}
+
+			c.field(sb, t, ch, n)
+			i += n
+		default:
+			sb.WriteByte(ch)
+			i++
+		}
+	}
+
+	return sb.String()
+}
+
+// writeQuoted writes the literal text, which starts after an apostrophe at i, and returns the index after its end
+func writeQuoted(sb *strings.Builder, pattern string, i int) int {
+	if i < len(pattern) && pattern[i] == '\'' {
+		sb.WriteByte('\'')
+		return i + 1
+	}
+
+	for i < len(pattern) {
+		if pattern[i] != '\'' {
+			sb.WriteByte(pattern[i])
+			i++
+
+			continue
+		}
+
+		if i+1 < len(pattern) && pattern[i+1] == '\'' {
+			sb.WriteByte('\'')
+			i += 2
+
+			continue
+		}
+
+		return i + 1
+	}
+
+	return i
+}
+
+// field writes the field of the pattern letter, which has been repeated n times. Unknown letters are literal.
+func (c *calendarData) field(sb *strings.Builder, t time.Time, letter byte, n int) {
+	switch letter {
+	case 'y':
+		if n == 2 {
+			writePadded(sb, t.Year()%100, 2)
+		} else {
+			writePadded(sb, t.Year(), n)
+		}
+	case 'M', 'L':
+		months, shortMonths := c.months, c.shortMonths
+		if letter == 'L' && c.standaloneMonths[0] != "" {
+			months, shortMonths = c.standaloneMonths, c.standaloneShortMonths
+		}
+
+		switch {
+		case n <= 2:
+			writePadded(sb, int(t.Month()), n)
+		case n == 3:
+			sb.WriteString(shortMonths[t.Month()-1])
+		default:
+			sb.WriteString(months[t.Month()-1])
+		}
+	case 'd':
+		writePadded(sb, t.Day(), n)
+	case 'E':
+		if n <= 3 {
+			sb.WriteString(c.shortWeekdays[t.Weekday()])
+		} else {
+			sb.WriteString(c.weekdays[t.Weekday()])
+		}
+	case 'a':
+		if t.Hour() < 12 {
+			sb.WriteString(c.am)
+		} else {
+			sb.WriteString(c.pm)
+		}
+	case 'h':
+		hour := t.Hour() % 12
+		if hour == 0 {
+			hour = 12
+		}
+
+		writePadded(sb, hour, n)
+	case 'H':
+		writePadded(sb, t.Hour(), n)
+	case 'K':
+		writePadded(sb, t.Hour()%12, n)
+	case 'k':
+		hour := t.Hour()
+		if hour == 0 {
+			hour = 24
+		}
+
+		writePadded(sb, hour, n)
+	case 'm':
+		writePadded(sb, t.Minute(), n)
+	case 's':
+		writePadded(sb, t.Second(), n)
+	case 'z':
+		// the long names of time zones are not available, so both use the abbreviation
+		zone, _ := t.Zone()
+		sb.WriteString(zone)
+	default:
+		sb.WriteString(strings.Repeat(string(letter), n))
+	}
+}
+
+// writePadded writes the number with leading zeros up to the given width
+func writePadded(sb *strings.Builder, v int, width int) {
+	str := strconv.Itoa(v)
+	for i := len(str); i < width; i++ {
+		sb.WriteByte('0')
+	}
+
+	sb.WriteString(str)
+}
+
+// durationUnits are the units of a Duration, without months and years, whose lengths vary
+// nolint: gochecknoglobals
+var durationUnits = []struct {
+	unit string
+	size time.Duration
+}{
+	{unitDay, 24 * time.Hour},
+	{unitHour, time.Hour},
+	{unitMinute, time.Minute},
+	{unitSecond, time.Second},
+}
+
+// duration formats the non-zero units of the duration rounded to seconds
+func (p *numberPrinter) duration(d time.Duration) string {
+	c, tag := p.calendar()
+
+	negative := d < 0
+	if negative {
+		d = -d
+	}
+
+	d = d.Round(time.Second)
+
+	var parts []string
+
+	for _, u := range durationUnits {
+		n := int64(d / u.size)
+		d -= time.Duration(n) * u.size
+
+		if n > 0 {
+			parts = append(parts, p.unit(c.durations[u.unit], tag, n))
+		}
+	}
+
+	if len(parts) == 0 {
+		parts = append(parts, p.unit(c.durations[unitSecond], tag, 0))
+	}
+
+	if negative {
+		return p.minus + p.list(parts, UnitList)
+	}
+
+	return p.list(parts, UnitList)
+}
+
+// relativeUnits are the units of a RelativeTime with their approximate length, from which on they are used
+// nolint: gochecknoglobals
+var relativeUnits = []struct {
+	unit string
+	size time.Duration
+}{
+	{unitYear, 365 * 24 * time.Hour},
+	{unitMonth, 30 * 24 * time.Hour},
+	{unitWeek, 7 * 24 * time.Hour},
+	{unitDay, 24 * time.Hour},
+	{unitHour, time.Hour},
+	{unitMinute, time.Minute},
+	{unitSecond, time.Second},
+}
+
+// relative formats the duration rounded in its largest unit, or just as now, if it is shorter than half a second
+func (p *numberPrinter) relative(d time.Duration) string {
+	c, tag := p.calendar()
+
+	patterns := c.future
+	if d < 0 {
+		d, patterns = -d, c.past
+	}
+
+	for _, u := range relativeUnits {
+		if d < u.size && u.unit != unitSecond {
+			continue
+		}
+
+		n := int64(math.Round(float64(d) / float64(u.size)))
+		if n == 0 {
+			return c.relativeNow
+		}
+
+		return p.unit(patterns[u.unit], tag, n)
+	}
+
+	return c.relativeNow
+}
+
+// unit formats the count by the pattern of its CLDR plural form, like "{0} days"
+func (p *numberPrinter) unit(patterns unitPatterns, tag language.Tag, n int64) string {
+	d := intDecimal(n)
+	i, v, w, f, t := d.Operands()
+
+	pattern, ok := patterns[formName(plural.Cardinal.MatchPlural(tag, i, v, w, f, t))]
+	if !ok {
+		pattern = patterns[other]
+	}
+
+	return strings.Replace(pattern, "{0}", p.decimal(d), 1)
+}
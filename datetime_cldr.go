@@ -0,0 +1,539 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// calendarData contains the CLDR gregorian calendar of a language. The patterns are indexed by Short, Medium,
+// Long and Full minus 1.
+type calendarData struct {
+	months, shortMonths [12]string
+
+	// standaloneMonths are used by L, like in "январь 2020", and are only declared if not equal to months
+	standaloneMonths, standaloneShortMonths [12]string
+
+	weekdays, shortWeekdays [7]string // weekdays start with sunday, like time.Weekday
+	am, pm                  string
+	date, time, dateTime    [4]string // dateTime combines the time {0} and date {1} by the date style
+	skeletons               map[string]string
+
+	relativeNow  string
+	future, past map[string]unitPatterns // future and past are the relative times by unit, like "in {0} days"
+	durations    map[string]unitPatterns // durations are the unit names by unit, like "{0} days"
+}
+
+// unitPatterns are the patterns of a unit by the CLDR plural form, like "{0} days"
+type unitPatterns map[string]string
+
+// oneOther returns the patterns for languages, which only distinguish one and other
+func oneOther(one, other string) unitPatterns {
+	return unitPatterns{"one": one, "other": other}
+}
+
+// constant returns the patterns for languages without plural forms
+func constant(pattern string) unitPatterns {
+	return unitPatterns{"other": pattern}
+}
+
+// the units of relative times and durations
+const (
+	unitYear   = "year"
+	unitMonth  = "month"
+	unitWeek   = "week"
+	unitDay    = "day"
+	unitHour   = "hour"
+	unitMinute = "minute"
+	unitSecond = "second"
+)
+
+// genericSkeletons are used, if a language does not declare a skeleton
+// nolint: gochecknoglobals
+var genericSkeletons = map[string]string{
+	"Hm":  "HH:mm",
+	"Hms": "HH:mm:ss",
+	"hm":  "h:mm a",
+	"hms": "h:mm:ss a",
+	"y":   "y",
+	"d":   "d",
+	"E":   "EEE",
+}
+
+// rootCalendar is used for all languages without calendar data. It uses the ISO 8601 notation, like the CLDR root
+// locale, but the english names.
+// nolint: gochecknoglobals
+var rootCalendar = func() *calendarData {
+	c := *calendars["en"]
+	c.date = [4]string{"y-MM-dd", "y-MM-dd", "y MMMM d", "y MMMM d, EEEE"}
+	c.time = [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"}
+	c.dateTime = [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"}
+	c.skeletons = map[string]string{
+		"yMd": "y-MM-dd", "yMMMd": "y MMM d", "yMMMMd": "y MMMM d", "yMMMEd": "y MMM d, EEE", "MMMd": "MMM d",
+		"MMMMd": "MMMM d", "MMMEd": "MMM d, EEE", "Md": "MM-dd", "yM": "y-MM", "yMMM": "y MMM", "yMMMM": "y MMMM",
+	}
+
+	return &c
+}()
+
+// calendars contains the CLDR data of the most common languages by their base language
+// nolint: gochecknoglobals
+var calendars = map[string]*calendarData{
+	"en": {
+		months: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September",
+			"October", "November", "December"},
+		shortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		am:            "AM",
+		pm:            "PM",
+		date:          [4]string{"M/d/yy", "MMM d, y", "MMMM d, y", "EEEE, MMMM d, y"},
+		time:          [4]string{"h:mm a", "h:mm:ss a", "h:mm:ss a z", "h:mm:ss a zzzz"},
+		dateTime:      [4]string{"{1}, {0}", "{1}, {0}", "{1} 'at' {0}", "{1} 'at' {0}"},
+		skeletons: map[string]string{
+			"yMd": "M/d/y", "yMMMd": "MMM d, y", "yMMMMd": "MMMM d, y", "yMMMEd": "EEE, MMM d, y",
+			"MMMd": "MMM d", "MMMMd": "MMMM d", "MMMEd": "EEE, MMM d", "Md": "M/d", "yM": "M/y",
+			"yMMM": "MMM y", "yMMMM": "MMMM y",
+		},
+		relativeNow: "now",
+		future: map[string]unitPatterns{
+			unitYear:   oneOther("in {0} year", "in {0} years"),
+			unitMonth:  oneOther("in {0} month", "in {0} months"),
+			unitWeek:   oneOther("in {0} week", "in {0} weeks"),
+			unitDay:    oneOther("in {0} day", "in {0} days"),
+			unitHour:   oneOther("in {0} hour", "in {0} hours"),
+			unitMinute: oneOther("in {0} minute", "in {0} minutes"),
+			unitSecond: oneOther("in {0} second", "in {0} seconds"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   oneOther("{0} year ago", "{0} years ago"),
+			unitMonth:  oneOther("{0} month ago", "{0} months ago"),
+			unitWeek:   oneOther("{0} week ago", "{0} weeks ago"),
+			unitDay:    oneOther("{0} day ago", "{0} days ago"),
+			unitHour:   oneOther("{0} hour ago", "{0} hours ago"),
+			unitMinute: oneOther("{0} minute ago", "{0} minutes ago"),
+			unitSecond: oneOther("{0} second ago", "{0} seconds ago"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    oneOther("{0} day", "{0} days"),
+			unitHour:   oneOther("{0} hour", "{0} hours"),
+			unitMinute: oneOther("{0} minute", "{0} minutes"),
+			unitSecond: oneOther("{0} second", "{0} seconds"),
+		},
+	},
+	"de": {
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September",
+			"Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.",
+			"Nov.", "Dez."},
+		weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag",
+			"Samstag"},
+		shortWeekdays: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		am:            "AM",
+		pm:            "PM",
+		date:          [4]string{"dd.MM.yy", "dd.MM.y", "d. MMMM y", "EEEE, d. MMMM y"},
+		time:          [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		dateTime:      [4]string{"{1}, {0}", "{1}, {0}", "{1} 'um' {0}", "{1} 'um' {0}"},
+		skeletons: map[string]string{
+			"yMd": "d.M.y", "yMMMd": "d. MMM y", "yMMMMd": "d. MMMM y", "yMMMEd": "EEE, d. MMM y",
+			"MMMd": "d. MMM", "MMMMd": "d. MMMM", "MMMEd": "EEE, d. MMM", "Md": "d.M.", "yM": "M/y",
+			"yMMM": "MMM y", "yMMMM": "MMMM y",
+		},
+		relativeNow: "jetzt",
+		future: map[string]unitPatterns{
+			unitYear:   oneOther("in {0} Jahr", "in {0} Jahren"),
+			unitMonth:  oneOther("in {0} Monat", "in {0} Monaten"),
+			unitWeek:   oneOther("in {0} Woche", "in {0} Wochen"),
+			unitDay:    oneOther("in {0} Tag", "in {0} Tagen"),
+			unitHour:   oneOther("in {0} Stunde", "in {0} Stunden"),
+			unitMinute: oneOther("in {0} Minute", "in {0} Minuten"),
+			unitSecond: oneOther("in {0} Sekunde", "in {0} Sekunden"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   oneOther("vor {0} Jahr", "vor {0} Jahren"),
+			unitMonth:  oneOther("vor {0} Monat", "vor {0} Monaten"),
+			unitWeek:   oneOther("vor {0} Woche", "vor {0} Wochen"),
+			unitDay:    oneOther("vor {0} Tag", "vor {0} Tagen"),
+			unitHour:   oneOther("vor {0} Stunde", "vor {0} Stunden"),
+			unitMinute: oneOther("vor {0} Minute", "vor {0} Minuten"),
+			unitSecond: oneOther("vor {0} Sekunde", "vor {0} Sekunden"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    oneOther("{0} Tag", "{0} Tage"),
+			unitHour:   oneOther("{0} Stunde", "{0} Stunden"),
+			unitMinute: oneOther("{0} Minute", "{0} Minuten"),
+			unitSecond: oneOther("{0} Sekunde", "{0} Sekunden"),
+		},
+	},
+	"fr": {
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre",
+			"octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.",
+			"nov.", "déc."},
+		weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		am:            "AM",
+		pm:            "PM",
+		date:          [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		time:          [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		dateTime:      [4]string{"{1} {0}", "{1}, {0}", "{1} 'à' {0}", "{1} 'à' {0}"},
+		skeletons: map[string]string{
+			"yMd": "dd/MM/y", "yMMMd": "d MMM y", "yMMMMd": "d MMMM y", "yMMMEd": "EEE d MMM y",
+			"MMMd": "d MMM", "MMMMd": "d MMMM", "MMMEd": "EEE d MMM", "Md": "dd/MM", "yM": "MM/y",
+			"yMMM": "MMM y", "yMMMM": "MMMM y",
+		},
+		relativeNow: "maintenant",
+		future: map[string]unitPatterns{
+			unitYear:   oneOther("dans {0} an", "dans {0} ans"),
+			unitMonth:  constant("dans {0} mois"),
+			unitWeek:   oneOther("dans {0} semaine", "dans {0} semaines"),
+			unitDay:    oneOther("dans {0} jour", "dans {0} jours"),
+			unitHour:   oneOther("dans {0} heure", "dans {0} heures"),
+			unitMinute: oneOther("dans {0} minute", "dans {0} minutes"),
+			unitSecond: oneOther("dans {0} seconde", "dans {0} secondes"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   oneOther("il y a {0} an", "il y a {0} ans"),
+			unitMonth:  constant("il y a {0} mois"),
+			unitWeek:   oneOther("il y a {0} semaine", "il y a {0} semaines"),
+			unitDay:    oneOther("il y a {0} jour", "il y a {0} jours"),
+			unitHour:   oneOther("il y a {0} heure", "il y a {0} heures"),
+			unitMinute: oneOther("il y a {0} minute", "il y a {0} minutes"),
+			unitSecond: oneOther("il y a {0} seconde", "il y a {0} secondes"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    oneOther("{0} jour", "{0} jours"),
+			unitHour:   oneOther("{0} heure", "{0} heures"),
+			unitMinute: oneOther("{0} minute", "{0} minutes"),
+			unitSecond: oneOther("{0} seconde", "{0} secondes"),
+		},
+	},
+	"es": {
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre",
+			"octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov",
+			"dic"},
+		weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		am:            "a. m.",
+		pm:            "p. m.",
+		date:          [4]string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		time:          [4]string{"H:mm", "H:mm:ss", "H:mm:ss z", "H:mm:ss (zzzz)"},
+		dateTime:      [4]string{"{1}, {0}", "{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		skeletons: map[string]string{
+			"yMd": "d/M/y", "yMMMd": "d MMM y", "yMMMMd": "d 'de' MMMM 'de' y", "yMMMEd": "EEE, d MMM y",
+			"MMMd": "d MMM", "MMMMd": "d 'de' MMMM", "MMMEd": "EEE, d MMM", "Md": "d/M", "yM": "M/y",
+			"yMMM": "MMM y", "yMMMM": "MMMM 'de' y", "Hm": "H:mm", "Hms": "H:mm:ss",
+		},
+		relativeNow: "ahora",
+		future: map[string]unitPatterns{
+			unitYear:   oneOther("dentro de {0} año", "dentro de {0} años"),
+			unitMonth:  oneOther("dentro de {0} mes", "dentro de {0} meses"),
+			unitWeek:   oneOther("dentro de {0} semana", "dentro de {0} semanas"),
+			unitDay:    oneOther("dentro de {0} día", "dentro de {0} días"),
+			unitHour:   oneOther("dentro de {0} hora", "dentro de {0} horas"),
+			unitMinute: oneOther("dentro de {0} minuto", "dentro de {0} minutos"),
+			unitSecond: oneOther("dentro de {0} segundo", "dentro de {0} segundos"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   oneOther("hace {0} año", "hace {0} años"),
+			unitMonth:  oneOther("hace {0} mes", "hace {0} meses"),
+			unitWeek:   oneOther("hace {0} semana", "hace {0} semanas"),
+			unitDay:    oneOther("hace {0} día", "hace {0} días"),
+			unitHour:   oneOther("hace {0} hora", "hace {0} horas"),
+			unitMinute: oneOther("hace {0} minuto", "hace {0} minutos"),
+			unitSecond: oneOther("hace {0} segundo", "hace {0} segundos"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    oneOther("{0} día", "{0} días"),
+			unitHour:   oneOther("{0} hora", "{0} horas"),
+			unitMinute: oneOther("{0} minuto", "{0} minutos"),
+			unitSecond: oneOther("{0} segundo", "{0} segundos"),
+		},
+	},
+	"it": {
+		months: [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto",
+			"settembre", "ottobre", "novembre", "dicembre"},
+		shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov",
+			"dic"},
+		weekdays: [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì",
+			"sabato"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		am:            "AM",
+		pm:            "PM",
+		date:          [4]string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		time:          [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		dateTime:      [4]string{"{1}, {0}", "{1}, {0}", "{1} {0}", "{1} {0}"},
+		skeletons: map[string]string{
+			"yMd": "d/M/y", "yMMMd": "d MMM y", "yMMMMd": "d MMMM y", "yMMMEd": "EEE d MMM y",
+			"MMMd": "d MMM", "MMMMd": "d MMMM", "MMMEd": "EEE d MMM", "Md": "d/M", "yM": "M/y",
+			"yMMM": "MMM y", "yMMMM": "MMMM y",
+		},
+		relativeNow: "ora",
+		future: map[string]unitPatterns{
+			unitYear:   oneOther("tra {0} anno", "tra {0} anni"),
+			unitMonth:  oneOther("tra {0} mese", "tra {0} mesi"),
+			unitWeek:   oneOther("tra {0} settimana", "tra {0} settimane"),
+			unitDay:    oneOther("tra {0} giorno", "tra {0} giorni"),
+			unitHour:   oneOther("tra {0} ora", "tra {0} ore"),
+			unitMinute: oneOther("tra {0} minuto", "tra {0} minuti"),
+			unitSecond: oneOther("tra {0} secondo", "tra {0} secondi"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   oneOther("{0} anno fa", "{0} anni fa"),
+			unitMonth:  oneOther("{0} mese fa", "{0} mesi fa"),
+			unitWeek:   oneOther("{0} settimana fa", "{0} settimane fa"),
+			unitDay:    oneOther("{0} giorno fa", "{0} giorni fa"),
+			unitHour:   oneOther("{0} ora fa", "{0} ore fa"),
+			unitMinute: oneOther("{0} minuto fa", "{0} minuti fa"),
+			unitSecond: oneOther("{0} secondo fa", "{0} secondi fa"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    oneOther("{0} giorno", "{0} giorni"),
+			unitHour:   oneOther("{0} ora", "{0} ore"),
+			unitMinute: oneOther("{0} minuto", "{0} minuti"),
+			unitSecond: oneOther("{0} secondo", "{0} secondi"),
+		},
+	},
+	"pt": {
+		months: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto",
+			"setembro", "outubro", "novembro", "dezembro"},
+		shortMonths: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.",
+			"nov.", "dez."},
+		weekdays: [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira",
+			"sexta-feira", "sábado"},
+		shortWeekdays: [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		am:            "AM",
+		pm:            "PM",
+		date:          [4]string{"dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		time:          [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		dateTime:      [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		skeletons: map[string]string{
+			"yMd": "dd/MM/y", "yMMMd": "d 'de' MMM 'de' y", "yMMMMd": "d 'de' MMMM 'de' y",
+			"yMMMEd": "EEE, d 'de' MMM 'de' y", "MMMd": "d 'de' MMM", "MMMMd": "d 'de' MMMM",
+			"MMMEd": "EEE, d 'de' MMM", "Md": "d/M", "yM": "MM/y", "yMMM": "MMM 'de' y", "yMMMM": "MMMM 'de' y",
+		},
+		relativeNow: "agora",
+		future: map[string]unitPatterns{
+			unitYear:   oneOther("em {0} ano", "em {0} anos"),
+			unitMonth:  oneOther("em {0} mês", "em {0} meses"),
+			unitWeek:   oneOther("em {0} semana", "em {0} semanas"),
+			unitDay:    oneOther("em {0} dia", "em {0} dias"),
+			unitHour:   oneOther("em {0} hora", "em {0} horas"),
+			unitMinute: oneOther("em {0} minuto", "em {0} minutos"),
+			unitSecond: oneOther("em {0} segundo", "em {0} segundos"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   oneOther("há {0} ano", "há {0} anos"),
+			unitMonth:  oneOther("há {0} mês", "há {0} meses"),
+			unitWeek:   oneOther("há {0} semana", "há {0} semanas"),
+			unitDay:    oneOther("há {0} dia", "há {0} dias"),
+			unitHour:   oneOther("há {0} hora", "há {0} horas"),
+			unitMinute: oneOther("há {0} minuto", "há {0} minutos"),
+			unitSecond: oneOther("há {0} segundo", "há {0} segundos"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    oneOther("{0} dia", "{0} dias"),
+			unitHour:   oneOther("{0} hora", "{0} horas"),
+			unitMinute: oneOther("{0} minuto", "{0} minutos"),
+			unitSecond: oneOther("{0} segundo", "{0} segundos"),
+		},
+	},
+	"nl": {
+		months: [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september",
+			"oktober", "november", "december"},
+		shortMonths:   [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		weekdays:      [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		shortWeekdays: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		am:            "a.m.",
+		pm:            "p.m.",
+		date:          [4]string{"dd-MM-y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		time:          [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		dateTime:      [4]string{"{1} {0}", "{1} {0}", "{1} 'om' {0}", "{1} 'om' {0}"},
+		skeletons: map[string]string{
+			"yMd": "d-M-y", "yMMMd": "d MMM y", "yMMMMd": "d MMMM y", "yMMMEd": "EEE d MMM y",
+			"MMMd": "d MMM", "MMMMd": "d MMMM", "MMMEd": "EEE d MMM", "Md": "d-M", "yM": "M-y",
+			"yMMM": "MMM y", "yMMMM": "MMMM y",
+		},
+		relativeNow: "nu",
+		future: map[string]unitPatterns{
+			unitYear:   constant("over {0} jaar"),
+			unitMonth:  oneOther("over {0} maand", "over {0} maanden"),
+			unitWeek:   oneOther("over {0} week", "over {0} weken"),
+			unitDay:    oneOther("over {0} dag", "over {0} dagen"),
+			unitHour:   constant("over {0} uur"),
+			unitMinute: oneOther("over {0} minuut", "over {0} minuten"),
+			unitSecond: oneOther("over {0} seconde", "over {0} seconden"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   constant("{0} jaar geleden"),
+			unitMonth:  oneOther("{0} maand geleden", "{0} maanden geleden"),
+			unitWeek:   oneOther("{0} week geleden", "{0} weken geleden"),
+			unitDay:    oneOther("{0} dag geleden", "{0} dagen geleden"),
+			unitHour:   constant("{0} uur geleden"),
+			unitMinute: oneOther("{0} minuut geleden", "{0} minuten geleden"),
+			unitSecond: oneOther("{0} seconde geleden", "{0} seconden geleden"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    oneOther("{0} dag", "{0} dagen"),
+			unitHour:   constant("{0} uur"),
+			unitMinute: oneOther("{0} minuut", "{0} minuten"),
+			unitSecond: oneOther("{0} seconde", "{0} seconden"),
+		},
+	},
+	"ru": {
+		months: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября",
+			"октября", "ноября", "декабря"},
+		shortMonths: [12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.", "июл.", "авг.", "сент.", "окт.",
+			"нояб.", "дек."},
+		standaloneMonths: [12]string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август",
+			"сентябрь", "октябрь", "ноябрь", "декабрь"},
+		standaloneShortMonths: [12]string{"янв.", "февр.", "март", "апр.", "май", "июнь", "июль", "авг.", "сент.",
+			"окт.", "нояб.", "дек."},
+		weekdays: [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница",
+			"суббота"},
+		shortWeekdays: [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		am:            "AM",
+		pm:            "PM",
+		date:          [4]string{"dd.MM.y", "d MMM y 'г'.", "d MMMM y 'г'.", "EEEE, d MMMM y 'г'."},
+		time:          [4]string{"HH:mm", "HH:mm:ss", "HH:mm:ss z", "HH:mm:ss zzzz"},
+		dateTime:      [4]string{"{1}, {0}", "{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		skeletons: map[string]string{
+			"yMd": "dd.MM.y", "yMMMd": "d MMM y 'г'.", "yMMMMd": "d MMMM y 'г'.", "yMMMEd": "EEE, d MMM y 'г'.",
+			"MMMd": "d MMM", "MMMMd": "d MMMM", "MMMEd": "EEE, d MMM", "Md": "dd.MM", "yM": "MM.y",
+			"yMMM": "LLL y 'г'.", "yMMMM": "LLLL y 'г'.",
+		},
+		relativeNow: "сейчас",
+		future: map[string]unitPatterns{
+			unitYear: {"one": "через {0} год", "few": "через {0} года", "many": "через {0} лет",
+				"other": "через {0} года"},
+			unitMonth: {"one": "через {0} месяц", "few": "через {0} месяца", "many": "через {0} месяцев",
+				"other": "через {0} месяца"},
+			unitWeek: {"one": "через {0} неделю", "few": "через {0} недели", "many": "через {0} недель",
+				"other": "через {0} недели"},
+			unitDay: {"one": "через {0} день", "few": "через {0} дня", "many": "через {0} дней",
+				"other": "через {0} дня"},
+			unitHour: {"one": "через {0} час", "few": "через {0} часа", "many": "через {0} часов",
+				"other": "через {0} часа"},
+			unitMinute: {"one": "через {0} минуту", "few": "через {0} минуты", "many": "через {0} минут",
+				"other": "через {0} минуты"},
+			unitSecond: {"one": "через {0} секунду", "few": "через {0} секунды", "many": "через {0} секунд",
+				"other": "через {0} секунды"},
+		},
+		past: map[string]unitPatterns{
+			unitYear: {"one": "{0} год назад", "few": "{0} года назад", "many": "{0} лет назад",
+				"other": "{0} года назад"},
+			unitMonth: {"one": "{0} месяц назад", "few": "{0} месяца назад", "many": "{0} месяцев назад",
+				"other": "{0} месяца назад"},
+			unitWeek: {"one": "{0} неделю назад", "few": "{0} недели назад", "many": "{0} недель назад",
+				"other": "{0} недели назад"},
+			unitDay: {"one": "{0} день назад", "few": "{0} дня назад", "many": "{0} дней назад",
+				"other": "{0} дня назад"},
+			unitHour: {"one": "{0} час назад", "few": "{0} часа назад", "many": "{0} часов назад",
+				"other": "{0} часа назад"},
+			unitMinute: {"one": "{0} минуту назад", "few": "{0} минуты назад", "many": "{0} минут назад",
+				"other": "{0} минуты назад"},
+			unitSecond: {"one": "{0} секунду назад", "few": "{0} секунды назад", "many": "{0} секунд назад",
+				"other": "{0} секунды назад"},
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    {"one": "{0} день", "few": "{0} дня", "many": "{0} дней", "other": "{0} дня"},
+			unitHour:   {"one": "{0} час", "few": "{0} часа", "many": "{0} часов", "other": "{0} часа"},
+			unitMinute: {"one": "{0} минута", "few": "{0} минуты", "many": "{0} минут", "other": "{0} минуты"},
+			unitSecond: {"one": "{0} секунда", "few": "{0} секунды", "many": "{0} секунд", "other": "{0} секунды"},
+		},
+	},
+	"ja": {
+		months: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月",
+			"12月"},
+		weekdays:      [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		shortWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		am:            "午前",
+		pm:            "午後",
+		date:          [4]string{"y/MM/dd", "y/MM/dd", "y年M月d日", "y年M月d日EEEE"},
+		time:          [4]string{"H:mm", "H:mm:ss", "H:mm:ss z", "H時mm分ss秒 zzzz"},
+		dateTime:      [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		skeletons: map[string]string{
+			"yMd": "y/M/d", "yMMMd": "y年M月d日", "yMMMMd": "y年M月d日", "yMMMEd": "y年M月d日(EEE)",
+			"MMMd": "M月d日", "MMMMd": "M月d日", "MMMEd": "M月d日(EEE)", "Md": "M/d", "yM": "y/M",
+			"yMMM": "y年M月", "yMMMM": "y年M月", "Hm": "H:mm", "Hms": "H:mm:ss", "hm": "aK:mm", "hms": "aK:mm:ss",
+		},
+		relativeNow: "今",
+		future: map[string]unitPatterns{
+			unitYear:   constant("{0} 年後"),
+			unitMonth:  constant("{0} か月後"),
+			unitWeek:   constant("{0} 週間後"),
+			unitDay:    constant("{0} 日後"),
+			unitHour:   constant("{0} 時間後"),
+			unitMinute: constant("{0} 分後"),
+			unitSecond: constant("{0} 秒後"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   constant("{0} 年前"),
+			unitMonth:  constant("{0} か月前"),
+			unitWeek:   constant("{0} 週間前"),
+			unitDay:    constant("{0} 日前"),
+			unitHour:   constant("{0} 時間前"),
+			unitMinute: constant("{0} 分前"),
+			unitSecond: constant("{0} 秒前"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    constant("{0} 日"),
+			unitHour:   constant("{0} 時間"),
+			unitMinute: constant("{0} 分"),
+			unitSecond: constant("{0} 秒"),
+		},
+	},
+	"zh": {
+		months: [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月",
+			"十二月"},
+		shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月",
+			"12月"},
+		weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		shortWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		am:            "上午",
+		pm:            "下午",
+		date:          [4]string{"y/M/d", "y年M月d日", "y年M月d日", "y年M月d日EEEE"},
+		time:          [4]string{"HH:mm", "HH:mm:ss", "z HH:mm:ss", "zzzz HH:mm:ss"},
+		dateTime:      [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		skeletons: map[string]string{
+			"yMd": "y/M/d", "yMMMd": "y年M月d日", "yMMMMd": "y年M月d日", "yMMMEd": "y年M月d日EEE",
+			"MMMd": "M月d日", "MMMMd": "M月d日", "MMMEd": "M月d日EEE", "Md": "M/d", "yM": "y/M",
+			"yMMM": "y年M月", "yMMMM": "y年M月", "hm": "ah:mm", "hms": "ah:mm:ss",
+		},
+		relativeNow: "现在",
+		future: map[string]unitPatterns{
+			unitYear:   constant("{0}年后"),
+			unitMonth:  constant("{0}个月后"),
+			unitWeek:   constant("{0}周后"),
+			unitDay:    constant("{0}天后"),
+			unitHour:   constant("{0}小时后"),
+			unitMinute: constant("{0}分钟后"),
+			unitSecond: constant("{0}秒钟后"),
+		},
+		past: map[string]unitPatterns{
+			unitYear:   constant("{0}年前"),
+			unitMonth:  constant("{0}个月前"),
+			unitWeek:   constant("{0}周前"),
+			unitDay:    constant("{0}天前"),
+			unitHour:   constant("{0}小时前"),
+			unitMinute: constant("{0}分钟前"),
+			unitSecond: constant("{0}秒钟前"),
+		},
+		durations: map[string]unitPatterns{
+			unitDay:    constant("{0}天"),
+			unitHour:   constant("{0}小时"),
+			unitMinute: constant("{0}分钟"),
+			unitSecond: constant("{0}秒钟"),
+		},
+	},
+}
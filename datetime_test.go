@@ -0,0 +1,141 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDateTime(t *testing.T) {
+	tm := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+
+	tests := []struct {
+		locale string
+		arg    interface{}
+		want   string
+	}{
+		{"en", DateTime{Time: tm}, "Mar 5, 2024, 2:07 PM"},
+		{"en", Date(tm, Full), "Tuesday, March 5, 2024"},
+		{"en", Date(tm, Short), "3/5/24"},
+		{"en", Time(tm, Long), "2:07:09 PM UTC"},
+		{"en", DateTime{Time: tm, DateStyle: Long, TimeStyle: Short}, "March 5, 2024 at 2:07 PM"},
+		{"en", Skeleton(tm, "yMMMEd"), "Tue, Mar 5, 2024"},
+		{"en", Skeleton(tm, "hm"), "2:07 PM"},
+		{"en", Skeleton(tm, "EEEE 'o''clock' HH"), "Tuesday o'clock 14"},
+		{"de", DateTime{Time: tm}, "05.03.2024, 14:07"},
+		{"de", Date(tm, Full), "Dienstag, 5. März 2024"},
+		{"de", DateTime{Time: tm, DateStyle: Long, TimeStyle: Short}, "5. März 2024 um 14:07"},
+		{"de", Skeleton(tm, "MMMEd"), "Di., 5. März"},
+		{"fr", Date(tm, Long), "5 mars 2024"},
+		{"es", Date(tm, Long), "5 de marzo de 2024"},
+		{"ru", Date(tm, Long), "5 марта 2024 г."},
+		{"ru", Skeleton(tm, "yMMMM"), "март 2024 г."},
+		{"ja", Date(tm, Full), "2024年3月5日火曜日"},
+		{"ja", Skeleton(tm, "hm"), "午後2:07"},
+		{"zh", Date(tm, Full), "2024年3月5日星期二"},
+		{"sv", DateTime{Time: tm}, "2024-03-05 14:07"},
+		{"sv", Skeleton(tm, "yMMMd"), "2024 Mar 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s: %+v", tt.locale, tt.arg), func(t *testing.T) {
+			str, err := NewText(tt.locale, "id", "%v").Text(tt.arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+}
+
+func TestDurationAndRelativeTime(t *testing.T) {
+	const day = 24 * time.Hour
+
+	now := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+
+	tests := []struct {
+		locale string
+		arg    interface{}
+		want   string
+	}{
+		{"en", Duration(26*time.Hour + 5*time.Minute), "1 day, 2 hours, 5 minutes"},
+		{"en", Duration(90 * time.Second), "1 minute, 30 seconds"},
+		{"en", Duration(0), "0 seconds"},
+		{"de", Duration(time.Hour + time.Minute), "1 Stunde und 1 Minute"},
+		{"ru", Duration(22 * time.Hour), "22 часа"},
+		{"ru", Duration(25 * time.Minute), "25 минут"},
+		{"en", RelativeTo(now.Add(-3*day), now), "3 days ago"},
+		{"en", RelativeTo(now.Add(2*time.Hour), now), "in 2 hours"},
+		{"en", RelativeTime(-time.Minute), "1 minute ago"},
+		{"en", RelativeTime(-14 * day), "2 weeks ago"},
+		{"en", RelativeTime(400 * day), "in 1 year"},
+		{"en", RelativeTime(100 * time.Millisecond), "now"},
+		{"de", RelativeTime(-3 * day), "vor 3 Tagen"},
+		{"de", RelativeTime(day), "in 1 Tag"},
+		{"fr", RelativeTime(-60 * day), "il y a 2 mois"},
+		{"ru", RelativeTime(-21 * time.Hour), "21 час назад"},
+		{"ru", RelativeTime(-3 * time.Hour), "3 часа назад"},
+		{"ru", RelativeTime(5 * time.Minute), "через 5 минут"},
+		{"ja", RelativeTime(-3 * day), "3 日前"},
+		{"en", RelativeTime(-290 * 365 * day), "290 years ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s: %v", tt.locale, tt.arg), func(t *testing.T) {
+			str, err := NewText(tt.locale, "id", "%s").Text(tt.arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+}
+
+func TestMessageTextDateTime(t *testing.T) {
+	tm := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+	pattern := "{d, date}|{d, date, full}|{d, time, short}|{d, date, ::MMMd}|{n, duration}|{n, duration, relative}"
+
+	tests := []struct {
+		locale string
+		args   Args
+		want   string
+	}{
+		{"en", Args{"d": tm, "n": 2 * time.Hour}, "Mar 5, 2024|Tuesday, March 5, 2024|2:07 PM|Mar 5|2 hours|in 2 hours"},
+		{"de", Args{"d": tm, "n": -2 * time.Hour}, "05.03.2024|Dienstag, 5. März 2024|14:07|5. März|-2 Stunden|" +
+			"vor 2 Stunden"},
+		{"en", Args{"d": "tomorrow", "n": 90}, "tomorrow|tomorrow|tomorrow|tomorrow|1 minute, 30 seconds|in 2 minutes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			str, err := MustMessageText(tt.locale, "id", pattern).Text(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+}
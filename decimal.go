@@ -0,0 +1,144 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxOperandDigits is the amount of digits, which are used exactly for an operand. The CLDR rules only look at
+// the last few digits, so larger numbers are approximated.
+const maxOperandDigits = 6
+
+// A Decimal is a number in the notation, which is shown to the user. In contrast to a float, it knows its visible
+// fraction digits, which select different plurals in many languages, like "1 hour" but "1.0 hours" in english or
+// "1,5 часа" in russian.
+type Decimal struct {
+	integer  string // integer contains the digits in front of the separator without leading zeros
+	fraction string // fraction contains the visible digits behind the separator, including trailing zeros
+	negative bool
+}
+
+// NewDecimal rounds the value to the given amount of fraction digits. A negative amount uses as many digits as
+// required to represent the value exactly. NaN and infinities are treated as 0.
+func NewDecimal(value float64, fractionDigits int) Decimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}
+	}
+
+	d, err := ParseDecimal(strconv.FormatFloat(value, 'f', fractionDigits, 64))
+	if err != nil {
+		// cannot happen, the f format is always a valid decimal
+		panic("assert: " + err.Error())
+	}
+
+	return d
+}
+
+// ParseDecimal parses a decimal like 1, -1.50 or 0.0. The separator is always a dot.
+func ParseDecimal(str string) (Decimal, error) {
+	d := Decimal{}
+	digits := str
+
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		d.negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+
+	integer, fraction := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		integer, fraction = digits[:i], digits[i+1:]
+	}
+
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) || strings.HasSuffix(digits, ".") {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", str)
+	}
+
+	d.integer = strings.TrimLeft(integer, "0")
+	d.fraction = fraction
+
+	return d, nil
+}
+
+func isDigits(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] < '0' || str[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the decimal in its normalized notation, like -1.50
+func (d Decimal) String() string {
+	sb := &strings.Builder{}
+	if d.negative {
+		sb.WriteByte('-')
+	}
+
+	if d.integer == "" {
+		sb.WriteByte('0')
+	} else {
+		sb.WriteString(d.integer)
+	}
+
+	if d.fraction != "" {
+		sb.WriteByte('.')
+		sb.WriteString(d.fraction)
+	}
+
+	return sb.String()
+}
+
+// Operands returns the CLDR plural operands of the absolute value, see also
+// https://unicode.org/reports/tr35/tr35-numbers.html#Operands
+//   - i is the integer digits
+//   - v is the number of visible fraction digits, with trailing zeros
+//   - w is the number of visible fraction digits, without trailing zeros
+//   - f is the visible fraction digits, with trailing zeros
+//   - t is the visible fraction digits, without trailing zeros
+//
+// Large integer or fraction digits are approximated, so that only their last digits are kept.
+func (d Decimal) Operands() (i, v, w, f, t int) {
+	trimmed := strings.TrimRight(d.fraction, "0")
+
+	return operand(d.integer), len(d.fraction), len(trimmed), operand(d.fraction), operand(trimmed)
+}
+
+// operand parses the digits. Numbers with more than maxOperandDigits keep their last digits and stay at least as
+// large as 10^maxOperandDigits, so that neither equality nor the modulo rules match by accident.
+func operand(digits string) int {
+	big := false
+	if len(digits) > maxOperandDigits {
+		big = strings.Trim(digits[:len(digits)-maxOperandDigits], "0") != ""
+		digits = digits[len(digits)-maxOperandDigits:]
+	}
+
+	n := 0
+	for i := 0; i < len(digits); i++ {
+		n = n*10 + int(digits[i]-'0')
+	}
+
+	if big {
+		n += int(math.Pow10(maxOperandDigits))
+	}
+
+	return n
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecimalOperands(t *testing.T) {
+	// the examples of https://unicode.org/reports/tr35/tr35-numbers.html#Operands
+	tests := []struct {
+		str           string
+		i, v, w, f, t int
+	}{
+		{"1", 1, 0, 0, 0, 0},
+		{"1.0", 1, 1, 0, 0, 0},
+		{"1.00", 1, 2, 0, 0, 0},
+		{"1.3", 1, 1, 1, 3, 3},
+		{"1.30", 1, 2, 1, 30, 3},
+		{"1.03", 1, 2, 2, 3, 3},
+		{"1.230", 1, 3, 2, 230, 23},
+		{"1200.50", 1200, 2, 1, 50, 5},
+		{"-1.5", 1, 1, 1, 5, 5},
+		{"007", 7, 0, 0, 0, 0},
+		{"123456789011", 1789011, 0, 0, 0, 0},
+		{"1000000000000", 1000000, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			d, err := ParseDecimal(tt.str)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if i, v, w, f, tr := d.Operands(); i != tt.i || v != tt.v || w != tt.w || f != tt.f || tr != tt.t {
+				t.Fatalf("expected %d %d %d %d %d but got %d %d %d %d %d", tt.i, tt.v, tt.w, tt.f, tt.t, i, v, w, f, tr)
+			}
+		})
+	}
+
+	for _, str := range []string{"", "-", "1.", ".5", "1,5", "1e3", "1.2.3", "abc"} {
+		if _, err := ParseDecimal(str); err == nil {
+			t.Fatalf("expected '%s' to be invalid", str)
+		}
+	}
+}
+
+func TestNewDecimal(t *testing.T) {
+	tests := []struct {
+		value  float64
+		digits int
+		want   string
+	}{
+		{1.5, 1, "1.5"},
+		{1, 1, "1.0"},
+		{1.255, 2, "1.25"},
+		{-0.5, 0, "-0"},
+		{0.125, -1, "0.125"},
+		{math.NaN(), 2, "0"},
+	}
+
+	for _, tt := range tests {
+		if got := NewDecimal(tt.value, tt.digits).String(); got != tt.want {
+			t.Fatalf("expected '%s' but got '%s'", tt.want, got)
+		}
+	}
+}
+
+func TestQuantityTextDecimal(t *testing.T) {
+	setup()
+
+	ImportValues(
+		NewQuantityText("en", "hours").One("%s hour").Other("%s hours"),
+		NewQuantityText("ru", "hours").One("%s час").Few("%s часа").Many("%s часов").Other("%s часа*"),
+		NewQuantityText("pt", "hours").One("%s hora").Other("%s horas"),
+		NewQuantityText("pt-PT", "hours").One("%s hora").Other("%s horas"),
+		NewQuantityText("lv", "hours").Zero("%s stundu").One("%s stunda").Other("%s stundas"),
+	)
+
+	tests := []struct {
+		locale   string
+		quantity string
+		want     string
+	}{
+		{"en", "1", "1 hour"},
+		{"en", "1.0", "1.0 hours"},
+		{"en", "1.5", "1.5 hours"},
+		{"ru", "1", "1 час"},
+		{"ru", "21", "21 час"},
+		{"ru", "5", "5 часов"},
+		{"ru", "1.5", "1.5 часа*"},
+		{"pt", "1.5", "1.5 hora"},
+		{"pt", "0.5", "0.5 hora"},
+		{"pt", "2.5", "2.5 horas"},
+		{"pt-PT", "1.5", "1.5 horas"},
+		{"lv", "0.0", "0.0 stundu"},
+		{"lv", "0.1", "0.1 stunda"},
+		{"lv", "0.11", "0.11 stundu"},
+		{"lv", "1.3", "1.3 stundas"},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.quantity)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		str, err := From(tt.locale).QuantityTextDecimal("hours", d, d.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if str != tt.want {
+			t.Fatalf("%s: expected '%s' but got '%s'", tt.locale, tt.want, str)
+		}
+	}
+}
@@ -9,36 +9,45 @@ import (
 )
 
 func init() {
+	RegisterStrings(i18n.DefaultBundle())
+}
+
+// RegisterStrings imports the package strings into the given bundle. It is called for the default bundle at init.
+func RegisterStrings(bundle *i18n.Bundle) {
 	var tag string
 
 	// from strings-de-DE.xml
 	tag = "de-DE"
 
-	i18n.ImportValue(i18n.NewText(tag, "app_name", "LeichteApp"))
-	i18n.ImportValue(i18n.NewText(tag, "bad_0", "@ ? < & ' \" \" '"))
-	i18n.ImportValue(i18n.NewText(tag, "bad_1", "hallo '"))
-	i18n.ImportValue(i18n.NewText(tag, "hello_world", "Hallo Welt"))
-	i18n.ImportValue(i18n.NewText(tag, "hello_x", "Hello %s"))
-	i18n.ImportValue(i18n.NewTextArray(tag, "selector_details_array", "first line", "second line", "third line", "fourth line"))
-	i18n.ImportValue(i18n.NewTextArray(tag, "selector_details_array2", "a", "b", "c", "d"))
-	i18n.ImportValue(i18n.NewQuantityText(tag, "x_has_y_cats").One("%[1]s has %[2]d cat").Other("the owner of %[2]d cats is %[1]s"))
-	i18n.ImportValue(i18n.NewQuantityText(tag, "x_has_y_cats2").One("%[1]s has %[2]d cat2").Other("the owner of %[2]d cats2 is %[1]s"))
-	i18n.ImportValue(i18n.NewText(tag, "x_runs_around_Y_and_sings_z", "%[1]s runs around the %[2]s and sings %[3]s"))
+	bundle.ImportValues(
+		i18n.NewText(tag, "app_name", "LeichteApp"),
+		i18n.NewText(tag, "bad_0", "@ ? < & ' \" \" '"),
+		i18n.NewText(tag, "bad_1", "hallo '"),
+		i18n.NewText(tag, "hello_world", "Hallo Welt"),
+		i18n.NewText(tag, "hello_x", "Hello %s"),
+		i18n.NewTextArray(tag, "selector_details_array", "first line", "second line", "third line", "fourth line"),
+		i18n.NewTextArray(tag, "selector_details_array2", "a", "b", "c", "d"),
+		i18n.NewQuantityText(tag, "x_has_y_cats").One("%[1]s has %[2]d cat").Other("the owner of %[2]d cats is %[1]s"),
+		i18n.NewQuantityText(tag, "x_has_y_cats2").One("%[1]s has %[2]d cat2").Other("the owner of %[2]d cats2 is %[1]s"),
+		i18n.NewText(tag, "x_runs_around_Y_and_sings_z", "%[1]s runs around the %[2]s and sings %[3]s"),
+	)
 	_ = tag
 
 	// from strings_test.xml
 	tag = "und"
 
-	i18n.ImportValue(i18n.NewText(tag, "app_name", "EasyApp"))
-	i18n.ImportValue(i18n.NewText(tag, "bad_0", "@ ? < & ' \" \" '"))
-	i18n.ImportValue(i18n.NewText(tag, "bad_1", "hello '"))
-	i18n.ImportValue(i18n.NewText(tag, "hello_world", "Hello World"))
-	i18n.ImportValue(i18n.NewText(tag, "hello_x", "Hello %s"))
-	i18n.ImportValue(i18n.NewTextArray(tag, "selector_details_array", "first line", "second line", "third line", "fourth line"))
-	i18n.ImportValue(i18n.NewTextArray(tag, "selector_details_array2", "a", "b", "c", "d"))
-	i18n.ImportValue(i18n.NewQuantityText(tag, "x_has_y_cats").One("%[1]s has %[2]d cat").Other("the owner of %[2]d cats is %[1]s"))
-	i18n.ImportValue(i18n.NewQuantityText(tag, "x_has_y_cats2").One("%[1]s has %[2]d cat2").Other("the owner of %[2]d cats2 is %[1]s"))
-	i18n.ImportValue(i18n.NewText(tag, "x_runs_around_Y_and_sings_z", "%[1]s runs around the %[2]s and sings %[3]s"))
+	bundle.ImportValues(
+		i18n.NewText(tag, "app_name", "EasyApp"),
+		i18n.NewText(tag, "bad_0", "@ ? < & ' \" \" '"),
+		i18n.NewText(tag, "bad_1", "hello '"),
+		i18n.NewText(tag, "hello_world", "Hello World"),
+		i18n.NewText(tag, "hello_x", "Hello %s"),
+		i18n.NewTextArray(tag, "selector_details_array", "first line", "second line", "third line", "fourth line"),
+		i18n.NewTextArray(tag, "selector_details_array2", "a", "b", "c", "d"),
+		i18n.NewQuantityText(tag, "x_has_y_cats").One("%[1]s has %[2]d cat").Other("the owner of %[2]d cats is %[1]s"),
+		i18n.NewQuantityText(tag, "x_has_y_cats2").One("%[1]s has %[2]d cat2").Other("the owner of %[2]d cats2 is %[1]s"),
+		i18n.NewText(tag, "x_runs_around_Y_and_sings_z", "%[1]s runs around the %[2]s and sings %[3]s"),
+	)
 	_ = tag
 
 }
@@ -53,6 +62,11 @@ func NewResources(locale string) Resources {
 	return Resources{i18n.From(locale)}
 }
 
+// NewResourcesFromBundle creates a new localized resource instance from the given bundle.
+func NewResourcesFromBundle(bundle *i18n.Bundle, locale string) Resources {
+	return Resources{bundle.From(locale)}
+}
+
 // AppName returns a translated text for "EasyApp"
 func (r Resources) AppName() string {
 	str, err := r.res.Text("app_name")
@@ -125,6 +139,15 @@ func (r Resources) XHasYCats(quantity int, str0 string, num1 int) string {
 	return str
 }
 
+// XHasYCatsDecimal returns a translated text for "the owner of %[2]d cats is %[1]s" with a decimal quantity, like 1.5
+func (r Resources) XHasYCatsDecimal(quantity i18n.Decimal, str0 string, num1 int) string {
+	str, err := r.res.QuantityTextDecimal("x_has_y_cats", quantity, str0, num1)
+	if err != nil {
+		return fmt.Errorf("MISS!x_has_y_cats: %w", err).Error()
+	}
+	return str
+}
+
 // XHasYCats2 returns a translated text for "the owner of %[2]d cats2 is %[1]s"
 func (r Resources) XHasYCats2(quantity int, str0 string, num1 int) string {
 	str, err := r.res.QuantityText("x_has_y_cats2", quantity, str0, num1)
@@ -134,6 +157,15 @@ func (r Resources) XHasYCats2(quantity int, str0 string, num1 int) string {
 	return str
 }
 
+// XHasYCats2Decimal returns a translated text for "the owner of %[2]d cats2 is %[1]s" with a decimal quantity, like 1.5
+func (r Resources) XHasYCats2Decimal(quantity i18n.Decimal, str0 string, num1 int) string {
+	str, err := r.res.QuantityTextDecimal("x_has_y_cats2", quantity, str0, num1)
+	if err != nil {
+		return fmt.Errorf("MISS!x_has_y_cats2: %w", err).Error()
+	}
+	return str
+}
+
 // XRunsAroundYAndSingsZ returns a translated text for "%[1]s runs around the %[2]s and sings %[3]s"
 func (r Resources) XRunsAroundYAndSingsZ(str0 string, str1 string, str2 string) string {
 	str, err := r.res.Text("x_runs_around_Y_and_sings_z", str0, str1, str2)
@@ -154,7 +186,9 @@ func (r Resources) FuncMap() map[string]interface{} {
 	m["SelectorDetailsArray"] = r.SelectorDetailsArray
 	m["SelectorDetailsArray2"] = r.SelectorDetailsArray2
 	m["XHasYCats"] = r.XHasYCats
+	m["XHasYCatsDecimal"] = r.XHasYCatsDecimal
 	m["XHasYCats2"] = r.XHasYCats2
+	m["XHasYCats2Decimal"] = r.XHasYCats2Decimal
 	m["XRunsAroundYAndSingsZ"] = r.XRunsAroundYAndSingsZ
 	return m
 }
@@ -0,0 +1,107 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/android"
+	"io"
+	"strings"
+)
+
+// An Exporter serializes resources into a data format. It is the counterpart of an Importer.
+type Exporter interface {
+	// Export writes the given resources in a stable order into dst.
+	Export(src *Resources, dst io.Writer) error
+}
+
+// ErrUnsupportedValue indicates that the data format of an Exporter cannot hold the kind of value, like a select in
+// a PO file. Nothing is written in that case, so that no value is lost silently.
+type ErrUnsupportedValue struct {
+	Value  Value
+	Format string
+}
+
+func (e ErrUnsupportedValue) Error() string {
+	return located(fmt.Sprintf("the %s format cannot hold the %s %s.%s", e.Format, kindOf(e.Value), e.Value.Locale(),
+		e.Value.ID()), e.Value, nil)
+}
+
+// An AndroidExporter writes the android strings xml format. It is the inverse of the AndroidImporter, so
+// go indices like %[1]s are converted into %1$s and special chars are escaped again. A select becomes a
+// string-array with the tools:select attribute.
+type AndroidExporter struct {
+}
+
+// Export writes the given resources in a stable order into dst.
+func (a AndroidExporter) Export(src *Resources, dst io.Writer) error {
+	err := android.Write(dst, exportAndroid(src))
+	if err != nil {
+		return fmt.Errorf("failed to export android resources: %w", err)
+	}
+
+	return nil
+}
+
+// exportAndroid copies and converts the given i18n resources into android resources.
+func exportAndroid(src *Resources) android.Resources {
+	values := src.load()
+	res := android.Resources{}
+
+	for _, key := range sortedKeys(values) {
+		switch v := values[key].(type) {
+		case simpleValue:
+			res.Strings = append(res.Strings, android.String{
+				Name: v.Id,
+				Text: android.Encode(v.String),
+			})
+		case messageFormatValue:
+			res.Strings = append(res.Strings, android.String{
+				Name:   v.Id,
+				Format: android.FormatICU,
+				Text:   android.Escape(v.pattern),
+			})
+		case pluralValue:
+			pl := android.Plurals{Name: v.Id, Ordinal: v.ordinal}
+			for _, form := range v.forms() {
+				pl.Items = append(pl.Items, android.PluralItem{
+					Quantity: form.category,
+					Text:     android.Encode(form.text),
+				})
+			}
+
+			res.Plurals = append(res.Plurals, pl)
+		case selectValue:
+			selectors := append(v.selectors(), other)
+			arr := android.StringArray{Name: v.Id, Select: strings.Join(selectors, ",")}
+
+			for _, selector := range selectors {
+				arr.Items = append(arr.Items, android.Encode(v.get(selector)))
+			}
+
+			res.StringArrays = append(res.StringArrays, arr)
+		case arrayValue:
+			arr := android.StringArray{Name: v.Id, List: v.list}
+			for _, s := range v.Strings {
+				arr.Items = append(arr.Items, android.Encode(s))
+			}
+
+			res.StringArrays = append(res.StringArrays, arr)
+		}
+	}
+
+	return res
+}
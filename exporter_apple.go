@@ -0,0 +1,94 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/apple"
+	"io"
+	"strconv"
+)
+
+// An AppleStringsExporter writes the apple .strings format. It is the inverse of the AppleStringsImporter, so
+// arrays are written as "name[0]", "name[1]" and go directives like %[1]s become %1$@. Plurals are not
+// contained, use the AppleStringsDictExporter for them. Selects and ICU messages cannot be expressed and return an
+// ErrUnsupportedValue.
+type AppleStringsExporter struct {
+}
+
+// Export writes the given resources in a stable order into dst.
+func (a AppleStringsExporter) Export(src *Resources, dst io.Writer) error {
+	values := src.load()
+
+	var strs []apple.String
+
+	for _, key := range sortedKeys(values) {
+		switch v := values[key].(type) {
+		case simpleValue:
+			strs = append(strs, apple.String{Key: key, Value: apple.Encode(v.String), Comment: v.note})
+		case arrayValue:
+			for i, item := range v.Strings {
+				strs = append(strs, apple.String{Key: key + "[" + strconv.Itoa(i) + "]", Value: apple.Encode(item)})
+			}
+		case pluralValue:
+			// see AppleStringsDictExporter
+		default:
+			return fmt.Errorf("failed to export apple strings: %w", ErrUnsupportedValue{Value: v, Format: "strings"})
+		}
+	}
+
+	if err := apple.WriteStrings(dst, strs); err != nil {
+		return fmt.Errorf("failed to export apple strings: %w", err)
+	}
+
+	return nil
+}
+
+// An AppleStringsDictExporter writes the plurals in the apple .stringsdict format. It is the inverse of the
+// AppleStringsDictImporter. Any other kind of value is left to the AppleStringsExporter. Ordinals cannot be
+// expressed and return an ErrUnsupportedValue.
+type AppleStringsDictExporter struct {
+}
+
+// Export writes the given resources in a stable order into dst.
+func (a AppleStringsDictExporter) Export(src *Resources, dst io.Writer) error {
+	values := src.load()
+
+	var plurals []apple.Plural
+
+	for _, key := range sortedKeys(values) {
+		if v, ok := values[key].(pluralValue); ok {
+			if v.ordinal {
+				// a stringsdict only declares the NSStringPluralRuleType, which are the cardinal rules
+				return fmt.Errorf("failed to export apple stringsdict: %w",
+					ErrUnsupportedValue{Value: v, Format: "stringsdict"})
+			}
+
+			pl := apple.Plural{Key: key, Forms: make(map[string]string)}
+			for _, form := range v.forms() {
+				pl.Forms[form.category] = apple.Encode(form.text)
+			}
+
+			plurals = append(plurals, pl)
+		}
+	}
+
+	if err := apple.WriteStringsDict(dst, plurals); err != nil {
+		return fmt.Errorf("failed to export apple stringsdict: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/android"
+	"github.com/golangee/i18n/gettext"
+	"golang.org/x/text/language"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A POExporter writes gettext PO or POT files. It is the inverse of the POImporter: each key becomes the msgctxt
+// and the msgid contains the source text. The note of a value is written as extracted comment. Arrays are written
+// as "name[0]", "name[1]" and so on. The msgstr[n] indices of plurals follow the well known Plural-Forms of the
+// exported locale.
+type POExporter struct {
+	// Source provides the msgid and msgid_plural texts, usually the und resources. If nil, the exported resources
+	// are used, which results in a monolingual catalog.
+	Source *Resources
+
+	// Template writes a POT file with empty msgstr values, so that translators can start from the source language.
+	Template bool
+}
+
+// Export writes the given resources in a stable order into dst.
+func (a POExporter) Export(src *Resources, dst io.Writer) error {
+	catalog, err := a.exportCatalog(src)
+	if err != nil {
+		return fmt.Errorf("failed to export po resources: %w", err)
+	}
+
+	err = gettext.Write(dst, catalog)
+	if err != nil {
+		return fmt.Errorf("failed to export po resources: %w", err)
+	}
+
+	return nil
+}
+
+// exportCatalog copies and converts the given i18n resources into a gettext catalog. Selects, ordinals and ICU
+// messages cannot be expressed by gettext and return an ErrUnsupportedValue.
+func (a POExporter) exportCatalog(src *Resources) (gettext.Catalog, error) {
+	source := a.Source
+	if source == nil {
+		source = src
+	}
+
+	values, sourceValues := src.load(), source.load()
+
+	forms := gettext.DefaultPluralForms(src.tag.String())
+	catalog := gettext.Catalog{}
+	catalog.Set("MIME-Version", "1.0")
+	catalog.Set("Content-Type", "text/plain; charset=UTF-8")
+	catalog.Set("Content-Transfer-Encoding", "8bit")
+
+	if a.Template || src.tag == language.Und {
+		catalog.Set(gettext.HeaderLanguage, "")
+	} else {
+		catalog.Set(gettext.HeaderLanguage, strings.ReplaceAll(src.tag.String(), "-", "_"))
+	}
+
+	if a.Template {
+		catalog.Set(gettext.HeaderPluralForms, gettext.TemplatePluralForms)
+	} else {
+		catalog.Set(gettext.HeaderPluralForms, forms.String())
+	}
+
+	categories := poCategories(forms, src.tag)
+
+	for _, key := range sortedKeys(values) {
+		srcValue := sourceValues[key]
+		if srcValue == nil {
+			srcValue = values[key]
+		}
+
+		switch v := values[key].(type) {
+		case simpleValue:
+			msgid := v.String
+			if s, ok := srcValue.(simpleValue); ok {
+				msgid = s.String
+			}
+
+			entry := a.entry(key, msgid, v.String)
+			entry.ExtractedComments = notes(v.note)
+			catalog.Entries = append(catalog.Entries, entry)
+		case pluralValue:
+			if v.ordinal {
+				// gettext only knows the cardinal rules of the Plural-Forms header
+				return gettext.Catalog{}, ErrUnsupportedValue{Value: v, Format: "po"}
+			}
+
+			catalog.Entries = append(catalog.Entries, a.pluralEntry(key, srcValue, v, categories))
+		case arrayValue:
+			srcItems := v.Strings
+			if s, ok := srcValue.(arrayValue); ok {
+				srcItems = s.Strings
+			}
+
+			for i, item := range v.Strings {
+				msgid := item
+				if i < len(srcItems) {
+					msgid = srcItems[i]
+				}
+
+				catalog.Entries = append(catalog.Entries, a.entry(key+"["+strconv.Itoa(i)+"]", msgid, item))
+			}
+		default:
+			return gettext.Catalog{}, ErrUnsupportedValue{Value: v, Format: "po"}
+		}
+	}
+
+	return catalog, nil
+}
+
+func (a POExporter) entry(key, msgid, msgstr string) gettext.Entry {
+	entry := gettext.Entry{
+		MsgCtxt:    key,
+		HasMsgCtxt: true,
+		MsgID:      android.AsJavaFormat(msgid),
+	}
+
+	if !a.Template {
+		entry.MsgStr = android.AsJavaFormat(msgstr)
+	}
+
+	if len(ParsePrintf(msgid)) > 0 || len(ParsePrintf(msgstr)) > 0 {
+		entry.Flags = append(entry.Flags, gettext.FlagCFormat)
+	}
+
+	return entry
+}
+
+func (a POExporter) pluralEntry(key string, srcValue Value, v pluralValue, categories []string) gettext.Entry {
+	srcPlural, ok := srcValue.(pluralValue)
+	if !ok {
+		srcPlural = v
+	}
+
+	msgid := srcPlural.one
+	if msgid == "" {
+		msgid = srcPlural.other
+	}
+
+	entry := a.entry(key, msgid, "")
+	entry.MsgIDPlural = android.AsJavaFormat(srcPlural.other)
+	entry.ExtractedComments = notes(v.note)
+
+	if a.Template {
+		entry.MsgStrPlural = []string{"", ""}
+		return entry
+	}
+
+	for _, category := range categories {
+		text := v.get(category)
+		if text == "" {
+			text = v.other
+		}
+
+		entry.MsgStrPlural = append(entry.MsgStrPlural, android.AsJavaFormat(text))
+	}
+
+	if len(ParsePrintf(v.other)) > 0 && !entry.HasFlag(gettext.FlagCFormat) {
+		entry.Flags = append(entry.Flags, gettext.FlagCFormat)
+	}
+
+	return entry
+}
+
+// notes splits a note into extracted comment lines
+func notes(note string) []string {
+	if note == "" {
+		return nil
+	}
+
+	return strings.Split(note, "\n")
+}
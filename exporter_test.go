@@ -0,0 +1,230 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"errors"
+	"github.com/golangee/i18n/android"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// equalValues compares the values without their origin, which is lost by an export
+func equalValues(a, b Value) bool {
+	return reflect.DeepEqual(withoutOrigin(a), withoutOrigin(b))
+}
+
+func withoutOrigin(v Value) Value {
+	switch t := v.(type) {
+	case simpleValue:
+		t.origin = Origin{}
+		return t
+	case pluralValue:
+		t.origin = Origin{}
+		return t
+	case arrayValue:
+		t.origin = Origin{}
+		return t
+	case selectValue:
+		t.origin = Origin{}
+		return t
+	case messageFormatValue:
+		t.origin = Origin{}
+		return t
+	default:
+		return v
+	}
+}
+
+func TestAndroidExporter(t *testing.T) {
+	setup()
+
+	file, err := os.Open("example/strings_test.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	err = Import(AndroidImporter{}, "und", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = Export(AndroidExporter{}, "und", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := newResources(defaultBundle.res.Locale("und"))
+	err = AndroidImporter{}.Import(res, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org := From("und")
+	if !reflect.DeepEqual(res.Keys(), org.Keys()) {
+		t.Fatalf("expected %v but got %v", org.Keys(), res.Keys())
+	}
+
+	for _, key := range org.Keys() {
+		if !equalValues(res.Value(key), org.Value(key)) {
+			t.Fatalf("expected %+v but got %+v", org.Value(key), res.Value(key))
+		}
+	}
+
+	err = Export(AndroidExporter{}, "fr", buf)
+	if !errors.Is(err, ErrLocaleNotFound) {
+		t.Fatalf("expected ErrLocaleNotFound but got %v", err)
+	}
+}
+
+func TestAndroidExporterMessageFormat(t *testing.T) {
+	setup()
+
+	src := `<resources xmlns:tools="http://schemas.android.com/tools">
+    <string name="x_has_cats" tools:format="icu">{name} hat zu 100% {count, plural, one {eine Katze} other {# Katzen}}, nicht wahr\'\'s</string>
+</resources>`
+
+	if err := Import(AndroidImporter{}, "de", strings.NewReader(src)); err != nil {
+		t.Fatal(err)
+	}
+
+	str, err := From("de").Text("x_has_cats", Args{"name": "Nick", "count": 2})
+	if err != nil || str != "Nick hat zu 100% 2 Katzen, nicht wahr's" {
+		t.Fatalf("unexpected '%s': %v", str, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Export(AndroidExporter{}, "de", buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `<resources xmlns:tools="http://schemas.android.com/tools">`) ||
+		!strings.Contains(buf.String(), `<string name="x_has_cats" tools:format="icu">{name} hat zu 100%`) ||
+		!strings.Contains(buf.String(), `nicht wahr\'\'s</string>`) {
+		t.Fatalf("unexpected export:\n%s", buf.String())
+	}
+
+	res := newResources(defaultBundle.res.Locale("de"))
+	if err := (AndroidImporter{}).Import(res, bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalValues(res.Value("x_has_cats"), From("de").Value("x_has_cats")) {
+		t.Fatalf("expected %+v but got %+v", From("de").Value("x_has_cats"), res.Value("x_has_cats"))
+	}
+
+	err = Import(AndroidImporter{}, "de", strings.NewReader(`<resources>
+    <string name="hello">hello</string>
+    <string name="invalid" tools:format="icu">{count, plural, one {# cat}}</string>
+</resources>`))
+	if err == nil {
+		t.Fatal("expected an error for the missing other case")
+	}
+
+	if From("de").Value("hello") != nil {
+		t.Fatal("an invalid file must not be imported partially")
+	}
+}
+
+func TestAndroidExporterSelect(t *testing.T) {
+	setup()
+
+	src := `<resources xmlns:tools="http://schemas.android.com/tools">
+    <string-array name="x_invited_you" tools:select="female, other">
+        <item>%s hat dich zu ihrer Party eingeladen</item>
+        <item>%s hat dich eingeladen</item>
+    </string-array>
+</resources>`
+
+	if err := Import(AndroidImporter{}, "de", strings.NewReader(src)); err != nil {
+		t.Fatal(err)
+	}
+
+	str, err := From("de").SelectText("x_invited_you", "female", "Anna")
+	if err != nil || str != "Anna hat dich zu ihrer Party eingeladen" {
+		t.Fatalf("unexpected '%s': %v", str, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Export(AndroidExporter{}, "de", buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `<string-array name="x_invited_you" tools:select="female,other">`) {
+		t.Fatalf("unexpected export:\n%s", buf.String())
+	}
+
+	res := newResources(defaultBundle.res.Locale("de"))
+	if err := (AndroidImporter{}).Import(res, bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	if !equalValues(res.Value("x_invited_you"), From("de").Value("x_invited_you")) {
+		t.Fatalf("expected %+v but got %+v", From("de").Value("x_invited_you"), res.Value("x_invited_you"))
+	}
+
+	for _, invalid := range []string{
+		`<string-array name="x" tools:select="female,other"><item>a</item></string-array>`,
+		`<string-array name="x" tools:select="other,other"><item>a</item><item>b</item></string-array>`,
+		`<string-array name="x" tools:select="other" tools:list="unit"><item>a</item></string-array>`,
+	} {
+		err := Import(AndroidImporter{}, "de", strings.NewReader(`<resources xmlns:tools="`+android.ToolsNamespace+
+			`">`+invalid+`</resources>`))
+		if err == nil {
+			t.Fatalf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestExporterUnsupportedValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter Exporter
+		value    Value
+	}{
+		{"po select", POExporter{}, NewSelectText("de", "x").Case("female", "sie").Other("es")},
+		{"po message", POExporter{}, MustMessageText("de", "x", "{n, plural, one {# Katze} other {# Katzen}}")},
+		{"strings select", AppleStringsExporter{}, NewSelectText("de", "x").Case("female", "sie").Other("es")},
+		{"strings message", AppleStringsExporter{}, MustMessageText("de", "x", "{name}")},
+		{"po ordinal", POExporter{}, NewOrdinalText("de", "x").Other("%d.")},
+		{"stringsdict ordinal", AppleStringsDictExporter{}, NewOrdinalText("de", "x").Other("%d.")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			ImportValue(NewText("de", "a", "a"))
+			ImportValue(tt.value)
+
+			buf := &bytes.Buffer{}
+			err := Export(tt.exporter, "de", buf)
+
+			var unsupported ErrUnsupportedValue
+			if !errors.As(err, &unsupported) || unsupported.Value.ID() != "x" {
+				t.Fatalf("expected an unsupported value but got %v", err)
+			}
+
+			if buf.Len() != 0 {
+				t.Fatalf("expected nothing to be written but got\n%s", buf.String())
+			}
+		})
+	}
+}
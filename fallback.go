@@ -0,0 +1,52 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+// A FallbackChain returns the locales in the order in which they are searched, if a key is missing for the given
+// locale. The available locales are given in their translation priority. Unavailable locales are ignored.
+type FallbackChain func(tag language.Tag, available []language.Tag) []language.Tag
+
+// DefaultFallbackChain walks up the parents of the locale, e.g. de-AT to de, followed by all available locales in
+// their translation priority and finally the undefined default locale.
+func DefaultFallbackChain(tag language.Tag, available []language.Tag) []language.Tag {
+	var res []language.Tag
+	for parent := tag.Parent(); parent != language.Und; parent = parent.Parent() {
+		res = append(res, parent)
+	}
+
+	res = append(res, available...)
+
+	return append(res, language.Und)
+}
+
+// ParentFallbackChain only walks up the parents of the locale, e.g. de-AT to de and then to the undefined default
+// locale.
+func ParentFallbackChain(tag language.Tag, available []language.Tag) []language.Tag {
+	var res []language.Tag
+	for parent := tag.Parent(); parent != language.Und; parent = parent.Parent() {
+		res = append(res, parent)
+	}
+
+	return append(res, language.Und)
+}
+
+// NoFallbackChain disables any fallback, so that a missing key is always an error.
+func NoFallbackChain(tag language.Tag, available []language.Tag) []language.Tag {
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFallbackChain(t *testing.T) {
+	bundle := NewBundle()
+	bundle.ImportValue(NewText("und", "a", "und a"))
+	bundle.ImportValue(NewText("und", "b", "und b"))
+	bundle.ImportValue(NewText("und", "c", "und c"))
+	bundle.ImportValue(NewText("fr", "d", "fr d"))
+	bundle.ImportValue(NewText("de", "b", "de b"))
+	bundle.ImportValue(NewText("de-AT", "c", "de-AT c"))
+
+	tests := []struct {
+		chain  FallbackChain
+		id     string
+		want   string
+		served string
+	}{
+		{DefaultFallbackChain, "c", "de-AT c", "de-AT"},
+		{DefaultFallbackChain, "b", "de b", "de"},
+		{DefaultFallbackChain, "a", "und a", "und"},
+		{DefaultFallbackChain, "d", "fr d", "fr"},
+		{ParentFallbackChain, "a", "und a", "und"},
+		{ParentFallbackChain, "d", "", ""},
+		{NoFallbackChain, "b", "", ""},
+		{nil, "c", "de-AT c", "de-AT"},
+	}
+
+	for _, tt := range tests {
+		bundle.SetFallbackChain(tt.chain)
+
+		res := bundle.res.Get("de-AT")
+		value, served, err := res.Lookup(tt.id)
+
+		if tt.want == "" {
+			if !errors.Is(err, ErrTextNotFound) {
+				t.Fatalf("%s: expected ErrTextNotFound but got %v", tt.id, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		str, _ := value.Text()
+		if str != tt.want || served != tt.served {
+			t.Fatalf("%s: expected '%s' from %s but got '%s' from %s", tt.id, tt.want, tt.served, str, served)
+		}
+	}
+}
@@ -19,12 +19,14 @@ import (
 	. "github.com/dave/jennifer/jen"
 	"github.com/golangee/i18n/internal"
 	"github.com/iancoleman/strcase"
+	"go/token"
 	"golang.org/x/text/language"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 const stringsPrefix = "strings"
@@ -36,16 +38,113 @@ type resourceFile struct {
 }
 
 type packageTranslation struct {
-	pkg   *internal.Package
-	files []resourceFile
+	pkg    *internal.Package
+	files  []resourceFile
+	output string
 }
 
-func (t *packageTranslation) Emit() error {
+// ErrIdentifierClash indicates that the generated code would declare the same go identifier twice and therefore
+// would not compile, e.g. for the selectors "a-b" and "a_b" or for the keys "a_b" and "aB".
+type ErrIdentifierClash struct {
+	Name   string
+	Value0 Value
+	// Value1 is nil, if Value0 clashes with itself, like the selector "selector" with the selector type, or with a
+	// fixed declaration of the generated code, like Resources
+	Value1 Value
+}
+
+func (e ErrIdentifierClash) Error() string {
+	msg := fmt.Sprintf("the generated go identifier '%s' of %s.%s is not unique", e.Name, e.Value0.Locale(),
+		e.Value0.ID())
+	if e.Value1 != nil {
+		msg += fmt.Sprintf(", it is also declared by %s.%s", e.Value1.Locale(), e.Value1.ID())
+	}
+
+	return located(msg, e.Value0, e.Value1)
+}
+
+// validate checks the consistency of all locales of the package and the uniqueness of the generated identifiers
+func (t *packageTranslation) validate() error {
 	var tmp []*Resources
 	for _, res := range t.files {
 		tmp = append(tmp, res.values)
 	}
-	err := validate(tmp)
+
+	errs := t.validateIdentifiers()
+	if err := validate(tmp); err != nil {
+		list, ok := err.(ErrList)
+		if !ok {
+			return err
+		}
+
+		errs = append(list.Errs, errs...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return ErrList{errs}
+}
+
+// validateIdentifiers returns an ErrIdentifierClash for each generated identifier, which has already been declared.
+// The package level declarations and the methods of Resources have their own namespaces.
+func (t *packageTranslation) validateIdentifiers() []error {
+	var errs []error
+
+	decls := map[string]Value{"Resources": nil, "NewResources": nil, "NewResourcesFromBundle": nil,
+		"RegisterStrings": nil}
+	methods := map[string]Value{"FuncMap": nil}
+
+	declare := func(scope map[string]Value, name string, value Value) {
+		if other, ok := scope[name]; ok {
+			if other != nil && other.ID() == value.ID() {
+				other = nil
+			}
+
+			errs = append(errs, ErrIdentifierClash{Name: name, Value0: value, Value1: other})
+			return
+		}
+
+		scope[name] = value
+	}
+
+	for _, value := range t.collectValues() {
+		valueDecls, valueMethods := goDeclarations(value)
+		for _, name := range valueDecls {
+			declare(decls, name, value)
+		}
+
+		for _, name := range valueMethods {
+			declare(methods, name, value)
+		}
+	}
+
+	return errs
+}
+
+// goDeclarations returns the names of the package level declarations and of the Resources methods, which are
+// generated for the value
+func goDeclarations(value Value) (decls, methods []string) {
+	name := strcase.ToCamel(value.ID())
+	methods = append(methods, name)
+
+	if p, ok := value.(pluralValue); ok && !p.ordinal {
+		methods = append(methods, name+"Decimal")
+	}
+
+	if s, ok := value.(selectValue); ok {
+		decls = append(decls, name+"Selector")
+		for _, selector := range append(s.selectors(), other) {
+			decls = append(decls, name+goIdentifier(selector))
+		}
+	}
+
+	return decls, methods
+}
+
+func (t *packageTranslation) Emit() error {
+	err := t.validate()
 	if err != nil {
 		return err
 	}
@@ -55,17 +154,25 @@ func (t *packageTranslation) Emit() error {
 	file.HeaderComment("This file was generated by github.com/golangee/i18n")
 
 	// the value import
-	file.Func().Id("init").Params().BlockFunc(func(group *Group) {
+	file.Func().Id("init").Params().Block(
+		Id("RegisterStrings").Call(Qual("github.com/golangee/i18n", "DefaultBundle").Call()),
+	)
+
+	file.Comment("RegisterStrings imports the package strings into the given bundle. It is called for the default bundle at init.")
+	file.Func().Id("RegisterStrings").Params(Id("bundle").Op("*").Qual("github.com/golangee/i18n", "Bundle")).BlockFunc(func(group *Group) {
 		group.Var().Id("tag").String()
 		for _, resFile := range t.files {
 			group.Line()
 			group.Comment("from " + filepath.Base(resFile.filename))
 			group.Id("tag").Op("=").Lit(resFile.values.tag.String())
 			group.Line()
-			for _, k := range resFile.values.Keys() {
-				val := resFile.values.values[k]
-				val.goEmitImportValue(group)
-			}
+			// a single call per file publishes all values at once
+			group.Id("bundle").Dot("ImportValues").CustomFunc(Options{Open: "(", Close: ")", Separator: ",", Multi: true}, func(group *Group) {
+				for _, k := range resFile.values.Keys() {
+					val := resFile.values.Value(k)
+					val.goEmitImportValue(group)
+				}
+			})
 			group.Id("_").Op("=").Id("tag")
 		}
 		group.Line()
@@ -79,8 +186,15 @@ func (t *packageTranslation) Emit() error {
 	file.Func().Id("NewResources").Params(Id("locale").String()).Id("Resources").BlockFunc(func(group *Group) {
 		group.Return(Id("Resources").Op("{").Qual("github.com/golangee/i18n", "From").Call(Id("locale"))).Op("}")
 	})
+	file.Comment("NewResourcesFromBundle creates a new localized resource instance from the given bundle.")
+	file.Func().Id("NewResourcesFromBundle").Params(Id("bundle").Op("*").Qual("github.com/golangee/i18n", "Bundle"), Id("locale").String()).Id("Resources").BlockFunc(func(group *Group) {
+		group.Return(Id("Resources").Op("{").Id("bundle").Dot("From").Call(Id("locale"))).Op("}")
+	})
 	for _, value := range t.collectValues() {
 		file.Comment(strcase.ToCamel(value.ID()) + " returns a translated text for \"" + value.exampleText() + "\"")
+		if note := value.Note(); note != "" {
+			file.Comment(note)
+		}
 		file.Custom(Options{}, value.goEmitGetter())
 	}
 
@@ -90,12 +204,22 @@ func (t *packageTranslation) Emit() error {
 		group.Id("m").Op(":=").Make(Map(Id("string")).Id("interface{}"))
 		for _, value := range t.collectValues() {
 			methodName := strcase.ToCamel(value.ID())
+			if sel, ok := value.(selectValue); ok {
+				// templates cannot convert a string into the typed selector on their own
+				group.Id("m").Op("[").Lit(methodName).Op("]=").Add(sel.goEmitFuncMapEntry())
+				continue
+			}
+
 			group.Id("m").Op("[").Lit(methodName).Op("]=").Id("r").Dot(methodName)
+
+			if p, ok := value.(pluralValue); ok && !p.ordinal {
+				group.Id("m").Op("[").Lit(methodName + "Decimal").Op("]=").Id("r").Dot(methodName + "Decimal")
+			}
 		}
 		group.Return(Id("m"))
 	})
 
-	dstFname := filepath.Join(t.pkg.Dir, "strings_gen.go")
+	dstFname := filepath.Join(t.pkg.Dir, t.output)
 	f, err := os.Create(dstFname)
 	if err != nil {
 		return fmt.Errorf("cannot write to %s: %w", dstFname, err)
@@ -116,7 +240,7 @@ func (t *packageTranslation) Emit() error {
 func (t *packageTranslation) collectValues() []Value {
 	tmp := make(map[string]Value)
 	for _, file := range t.files {
-		for _, value := range file.values.values {
+		for _, value := range file.values.load() {
 			tmp[value.ID()] = value
 		}
 	}
@@ -137,10 +261,16 @@ type goGenerator struct {
 	dir          string
 	pgk          *internal.Package
 	translations []*packageTranslation
+	opts         ModuleOptions
 }
 
 func newGoGenerator(dir string) *goGenerator {
-	return &goGenerator{dir: dir}
+	return &goGenerator{dir: dir, opts: ModuleOptions{Dir: dir, Output: "strings_gen.go"}}
+}
+
+// newGoGeneratorWith expects options with defaults
+func newGoGeneratorWith(opts ModuleOptions) *goGenerator {
+	return &goGenerator{dir: opts.Dir, opts: opts}
 }
 
 // Scan identifies all available package translations
@@ -160,7 +290,7 @@ func (g *goGenerator) scanCandidates(root *internal.Package) error {
 	var androidTranslationFiles []resourceFile
 	for _, file := range root.ListFiles() {
 		fname := filepath.Base(file)
-		if strings.HasPrefix(fname, stringsPrefix) && strings.HasSuffix(fname, stringsPostfix) {
+		if strings.HasPrefix(fname, stringsPrefix) && strings.HasSuffix(fname, stringsPostfix) && g.opts.selected(file) {
 			localeName := fname[len(stringsPrefix) : len(fname)-len(stringsPostfix)]
 			tag := language.Make(localeName)
 			res := newResources(tag)
@@ -181,8 +311,9 @@ func (g *goGenerator) scanCandidates(root *internal.Package) error {
 	}
 	if len(androidTranslationFiles) > 0 {
 		g.translations = append(g.translations, &packageTranslation{
-			pkg:   root,
-			files: androidTranslationFiles,
+			pkg:    root,
+			files:  androidTranslationFiles,
+			output: g.opts.Output,
 		})
 	}
 
@@ -207,13 +338,36 @@ func (g *goGenerator) Emit() error {
 
 func (p pluralValue) goEmitGetter() *Statement {
 	params := ParsePrintf(p.other)
-	return Func().Params(Id("r").Id("Resources")).Id(strcase.ToCamel(p.ID())).ParamsFunc(func(group *Group) {
-		group.Id("quantity").Int()
+	quantity, method := "quantity", "QuantityText"
+
+	if p.ordinal {
+		quantity, method = "ordinal", "OrdinalText"
+	}
+
+	getter := p.goEmitQuantityGetter(params, strcase.ToCamel(p.ID()), Id(quantity).Int(), quantity, method)
+	if p.ordinal {
+		return getter
+	}
+
+	// a cardinal plural may also be selected by the visible fraction digits
+	name := strcase.ToCamel(p.ID()) + "Decimal"
+
+	return getter.Line().Line().
+		Comment(name + " returns a translated text for \"" + p.exampleText() + "\" with a decimal quantity, like 1.5").
+		Line().
+		Add(p.goEmitQuantityGetter(params, name, Id("quantity").Qual("github.com/golangee/i18n", "Decimal"),
+			"quantity", "QuantityTextDecimal"))
+}
+
+func (p pluralValue) goEmitQuantityGetter(params []PrintfFormatSpecifier, name string, param *Statement,
+	quantity, method string) *Statement {
+	return Func().Params(Id("r").Id("Resources")).Id(name).ParamsFunc(func(group *Group) {
+		group.Add(param)
 		emitParams(params, group)
 	}).String().BlockFunc(func(group *Group) {
-		group.Id("str").Op(",").Id("err").Op(":=").Id("r").Dot("res").Dot("QuantityText").ParamsFunc(func(group *Group) {
+		group.Id("str").Op(",").Id("err").Op(":=").Id("r").Dot("res").Dot(method).ParamsFunc(func(group *Group) {
 			group.Lit(p.ID())
-			group.Id("quantity")
+			group.Id(quantity)
 			emitCallParams(params, group)
 		})
 
@@ -226,7 +380,12 @@ func (p pluralValue) exampleText() string {
 }
 
 func (p pluralValue) goEmitImportValue(group *Group) {
-	call := Qual("github.com/golangee/i18n", "NewQuantityText").Params(Id("tag"), Lit(p.Id))
+	builder := "NewQuantityText"
+	if p.ordinal {
+		builder = "NewOrdinalText"
+	}
+
+	call := Qual("github.com/golangee/i18n", builder).Params(Id("tag"), Lit(p.Id))
 	if len(p.zero) > 0 {
 		call = call.Dot("Zero").Params(Lit(p.zero))
 	}
@@ -247,7 +406,7 @@ func (p pluralValue) goEmitImportValue(group *Group) {
 		call = call.Dot("Other").Params(Lit(p.other))
 	}
 
-	group.Qual("github.com/golangee/i18n", "ImportValue").Params(call)
+	group.Add(call)
 }
 
 func emitParams(params []PrintfFormatSpecifier, group *Group) {
@@ -257,8 +416,10 @@ func emitParams(params []PrintfFormatSpecifier, group *Group) {
 			group.Id("num" + strconv.Itoa(i)).Int()
 		case 'f':
 			group.Id("fl" + strconv.Itoa(i)).Float64()
-		case 's':
+		case 's', 'q':
 			group.Id("str" + strconv.Itoa(i)).String()
+		case 't':
+			group.Id("flag" + strconv.Itoa(i)).Bool()
 		default:
 			group.Id("val" + strconv.Itoa(i)).Interface()
 		}
@@ -272,8 +433,10 @@ func emitCallParams(params []PrintfFormatSpecifier, group *Group) {
 			group.Id("num" + strconv.Itoa(i))
 		case 'f':
 			group.Id("fl" + strconv.Itoa(i))
-		case 's':
+		case 's', 'q':
 			group.Id("str" + strconv.Itoa(i))
+		case 't':
+			group.Id("flag" + strconv.Itoa(i))
 		default:
 			group.Id("val" + strconv.Itoa(i))
 		}
@@ -305,10 +468,14 @@ func (s simpleValue) exampleText() string {
 
 func (s simpleValue) goEmitImportValue(group *Group) {
 	call := Qual("github.com/golangee/i18n", "NewText").Params(Id("tag"), Lit(s.Id), Lit(s.String))
-	group.Qual("github.com/golangee/i18n", "ImportValue").Params(call)
+	group.Add(call)
 }
 
 func (a arrayValue) goEmitGetter() *Statement {
+	if a.list != "" {
+		return a.goEmitListGetter()
+	}
+
 	return Func().Params(Id("r").Id("Resources")).Id(strcase.ToCamel(a.ID())).Params().Op("[]").String().BlockFunc(func(group *Group) {
 		group.Id("str").Op(",").Id("err").Op(":=").Id("r").Dot("res").Dot("TextArray").Params(Lit(a.ID()))
 
@@ -317,8 +484,27 @@ func (a arrayValue) goEmitGetter() *Statement {
 	})
 }
 
+// goEmitListGetter returns the items joined into a locale-correct sentence, like "a, b, and c"
+func (a arrayValue) goEmitListGetter() *Statement {
+	style, _ := parseListStyle(a.list)
+	styleName := strcase.ToCamel(style.String()) + "List"
+
+	return Func().Params(Id("r").Id("Resources")).Id(strcase.ToCamel(a.ID())).Params().String().BlockFunc(func(group *Group) {
+		group.Id("str").Op(",").Id("err").Op(":=").Id("r").Dot("res").Dot("TextArray").Params(Lit(a.ID()))
+
+		group.If(Id("err").Op("!=").Nil()).Block(Return(Qual("fmt", "Errorf").Call(Lit("MISS!" + a.ID() + ": %w").Op(",").Id("err")).Dot("Error").Call()))
+		group.Return(Id("r").Dot("res").Dot("List").Call(Id("str"), Qual("github.com/golangee/i18n", styleName)))
+	})
+}
+
 func (a arrayValue) exampleText() string {
+	if a.list != "" {
+		style, _ := parseListStyle(a.list)
+		return printerOf(a.tag).list(a.Strings, style)
+	}
+
 	str, _ := a.Text()
+
 	return str
 }
 
@@ -329,5 +515,137 @@ func (a arrayValue) goEmitImportValue(group *Group) {
 		}
 	})
 	call := Qual("github.com/golangee/i18n", "NewTextArray").Params(Id("tag"), Lit(a.Id), varArgs)
-	group.Qual("github.com/golangee/i18n", "ImportValue").Params(call)
+	group.Add(call)
+}
+
+func (s selectValue) goEmitGetter() *Statement {
+	params := ParsePrintf(s.other)
+	name := strcase.ToCamel(s.ID())
+	selectorType := name + "Selector"
+
+	getter := Func().Params(Id("r").Id("Resources")).Id(name).ParamsFunc(func(group *Group) {
+		group.Id("selector").Id(selectorType)
+		emitParams(params, group)
+	}).String().BlockFunc(func(group *Group) {
+		group.Id("str").Op(",").Id("err").Op(":=").Id("r").Dot("res").Dot("SelectText").ParamsFunc(func(group *Group) {
+			group.Lit(s.ID())
+			group.String().Call(Id("selector"))
+			emitCallParams(params, group)
+		})
+		emitCheckReturn(s, group)
+	})
+
+	consts := Const().DefsFunc(func(group *Group) {
+		for _, selector := range append(s.selectors(), other) {
+			group.Id(name + goIdentifier(selector)).Id(selectorType).Op("=").Lit(selector)
+		}
+	})
+
+	return getter.Line().Line().
+		Comment(selectorType + " chooses the case of " + name + ".").Line().
+		Type().Id(selectorType).String().Line().Line().
+		Comment("The cases of " + name + ", unknown selectors use the other case.").Line().
+		Add(consts)
+}
+
+// goEmitFuncMapEntry returns a func which accepts the selector as a string and delegates to the typed getter
+func (s selectValue) goEmitFuncMapEntry() *Statement {
+	params := ParsePrintf(s.other)
+	name := strcase.ToCamel(s.ID())
+
+	return Func().ParamsFunc(func(group *Group) {
+		group.Id("selector").String()
+		emitParams(params, group)
+	}).String().Block(
+		Return(Id("r").Dot(name).CallFunc(func(group *Group) {
+			group.Id(name + "Selector").Call(Id("selector"))
+			emitCallParams(params, group)
+		})),
+	)
+}
+
+// goIdentifier converts the selector into the camel case suffix of a go identifier, dropping any other chars
+func goIdentifier(selector string) string {
+	sb := &strings.Builder{}
+	for _, r := range strcase.ToCamel(selector) {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+func (s selectValue) exampleText() string {
+	return s.other
+}
+
+func (s selectValue) goEmitImportValue(group *Group) {
+	call := Qual("github.com/golangee/i18n", "NewSelectText").Params(Id("tag"), Lit(s.Id))
+	for _, c := range s.cases {
+		call = call.Dot("Case").Params(Lit(c.selector), Lit(c.text))
+	}
+
+	call = call.Dot("Other").Params(Lit(s.other))
+
+	group.Add(call)
+}
+
+func (m messageFormatValue) goEmitGetter() *Statement {
+	args := m.arguments()
+
+	return Func().Params(Id("r").Id("Resources")).Id(strcase.ToCamel(m.ID())).ParamsFunc(func(group *Group) {
+		for _, arg := range args {
+			group.Id(goParamName(arg.Name)).Add(goType(argumentType(arg)))
+		}
+	}).String().BlockFunc(func(group *Group) {
+		named := Qual("github.com/golangee/i18n", "Args").Values(DictFunc(func(dict Dict) {
+			for _, arg := range args {
+				dict[Lit(arg.Name)] = Id(goParamName(arg.Name))
+			}
+		}))
+
+		group.Id("str").Op(",").Id("err").Op(":=").Id("r").Dot("res").Dot("Text").Params(Lit(m.ID()), named)
+		emitCheckReturn(m, group)
+	})
+}
+
+// goParamName converts the ICU argument name into a parameter name, which neither clashes with a keyword nor with
+// the receiver and the locals of the generated accessor.
+func goParamName(name string) string {
+	param := strcase.ToLowerCamel(goIdentifier(name))
+	switch {
+	case param == "":
+		return "arg"
+	case token.IsKeyword(param), param == "r", param == "str", param == "err", unicode.IsDigit([]rune(param)[0]):
+		return "arg" + strcase.ToCamel(param)
+	default:
+		return param
+	}
+}
+
+// goType returns the statement for the given type name of argumentType
+func goType(typ string) *Statement {
+	switch typ {
+	case "int":
+		return Int()
+	case "float64":
+		return Float64()
+	case "string":
+		return String()
+	case "time.Time":
+		return Qual("time", "Time")
+	case "time.Duration":
+		return Qual("time", "Duration")
+	default:
+		return Interface()
+	}
+}
+
+func (m messageFormatValue) exampleText() string {
+	return m.pattern
+}
+
+func (m messageFormatValue) goEmitImportValue(group *Group) {
+	group.Add(Qual("github.com/golangee/i18n", "MustMessageText").Params(Id("tag"), Lit(m.Id), Lit(m.pattern)))
 }
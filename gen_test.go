@@ -15,6 +15,15 @@
 package i18n
 
 import (
+	"fmt"
+	"github.com/dave/jennifer/jen"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -34,3 +43,270 @@ func Test_goGenerator_Scan(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGoEmitOrdinal(t *testing.T) {
+	value := NewOrdinalText("en", "nth_order").One("your %dst order").Other("your %dth order").(pluralValue)
+
+	getter := fmt.Sprintf("%#v", value.goEmitGetter())
+	if !strings.Contains(getter, "NthOrder(ordinal int, num0 int) string") ||
+		!strings.Contains(getter, `r.res.OrdinalText("nth_order", ordinal, num0)`) {
+		t.Fatalf("unexpected getter:\n%s", getter)
+	}
+
+	group := &jen.Group{}
+	value.goEmitImportValue(group)
+
+	if importValue := fmt.Sprintf("%#v", group); !strings.Contains(importValue, "i18n.NewOrdinalText(tag, \"nth_order\")") {
+		t.Fatalf("unexpected import:\n%s", importValue)
+	}
+}
+
+func TestGoEmitDecimal(t *testing.T) {
+	value := NewQuantityText("en", "x_hours").One("%s hour").Other("%s hours").(pluralValue)
+
+	getter := fmt.Sprintf("%#v", value.goEmitGetter())
+	if !strings.Contains(getter, "XHoursDecimal(quantity i18n.Decimal, str0 string) string") ||
+		!strings.Contains(getter, `r.res.QuantityTextDecimal("x_hours", quantity, str0)`) {
+		t.Fatalf("unexpected getter:\n%s", getter)
+	}
+
+	ordinal := NewOrdinalText("en", "nth_order").One("your %dst order").Other("your %dth order").(pluralValue)
+	if getter := fmt.Sprintf("%#v", ordinal.goEmitGetter()); strings.Contains(getter, "Decimal") {
+		t.Fatalf("ordinals have no decimal getter:\n%s", getter)
+	}
+}
+
+func TestGoEmitSelect(t *testing.T) {
+	value := NewSelectText("en", "x_invited_you").Case("female", "%s invited you to her party").
+		Case("non-binary", "%s invited you to their party").Other("%s invited you").(selectValue)
+
+	file := jen.NewFile("example")
+	file.Add(value.goEmitGetter())
+	file.Var().Id("_").Op("=").Add(value.goEmitFuncMapEntry())
+
+	src := fmt.Sprintf("%#v", file)
+	for _, want := range []string{
+		"func (r Resources) XInvitedYou(selector XInvitedYouSelector, str0 string) string",
+		`r.res.SelectText("x_invited_you", string(selector), str0)`,
+		"type XInvitedYouSelector string",
+		`XInvitedYouFemale    XInvitedYouSelector = "female"`,
+		`XInvitedYouNonBinary XInvitedYouSelector = "non-binary"`,
+		`XInvitedYouOther     XInvitedYouSelector = "other"`,
+		"return r.XInvitedYou(XInvitedYouSelector(selector), str0)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Fatalf("expected '%s' in:\n%s", want, src)
+		}
+	}
+
+	group := &jen.Group{}
+	value.goEmitImportValue(group)
+
+	if importValue := fmt.Sprintf("%#v", group); !strings.Contains(importValue,
+		`i18n.NewSelectText(tag, "x_invited_you").Case("female", "%s invited you to her party")`) {
+		t.Fatalf("unexpected import:\n%s", importValue)
+	}
+}
+
+func TestGoEmitMessageFormat(t *testing.T) {
+	value := MustMessageText("en", "x_has_cats",
+		"{user-name} has {count, plural, one {# cat} other {# cats}} since {since, date, short} {type}").(messageFormatValue)
+
+	file := jen.NewFile("example")
+	file.Add(value.goEmitGetter())
+
+	src := fmt.Sprintf("%#v", file)
+	for _, want := range []string{
+		"func (r Resources) XHasCats(count int, since time.Time, argType string, userName string) string",
+		`r.res.Text("x_has_cats", i18n.Args{`,
+		`"count":     count,`,
+		`"type":      argType,`,
+		`"user-name": userName,`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Fatalf("expected '%s' in:\n%s", want, src)
+		}
+	}
+
+	group := &jen.Group{}
+	value.goEmitImportValue(group)
+
+	if importValue := fmt.Sprintf("%#v", group); !strings.Contains(importValue, `i18n.MustMessageText(tag, "x_has_cats", "{user-name} has`) {
+		t.Fatalf("unexpected import:\n%s", importValue)
+	}
+}
+
+func TestGoEmitDateTime(t *testing.T) {
+	value := MustMessageText("en", "next_run",
+		"{job} runs {at, date, ::yMMMd} for {timeout, duration}").(messageFormatValue)
+
+	file := jen.NewFile("example")
+	file.Add(value.goEmitGetter())
+
+	want := "func (r Resources) NextRun(at time.Time, job string, timeout time.Duration) string"
+	if src := fmt.Sprintf("%#v", file); !strings.Contains(src, want) {
+		t.Fatalf("expected '%s' in:\n%s", want, src)
+	}
+}
+
+func TestGoEmitList(t *testing.T) {
+	value := arrayValue{Id: "toppings", locale: "en", Strings: []string{"cheese", "ham"}, list: "disjunction"}
+
+	file := jen.NewFile("example")
+	file.Add(value.goEmitGetter())
+
+	src := fmt.Sprintf("%#v", file)
+	for _, want := range []string{
+		"func (r Resources) Toppings() string",
+		`str, err := r.res.TextArray("toppings")`,
+		"return r.res.List(str, i18n.DisjunctionList)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Fatalf("expected '%s' in:\n%s", want, src)
+		}
+	}
+}
+
+func TestGoEmitAndroidSelect(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main.go": "package main\n",
+		"strings.xml": `<resources xmlns:tools="http://schemas.android.com/tools">
+    <string-array name="x_invited_you" tools:select="female,other">
+        <item>%s invited you to her party</item>
+        <item>%s invited you</item>
+    </string-array>
+</resources>`,
+	})
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	translations, err := Scan(ModuleOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := translations.Emit(); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := ioutil.ReadFile(filepath.Join(dir, "strings_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"func (r Resources) XInvitedYou(selector XInvitedYouSelector, str0 string) string",
+		`XInvitedYouFemale XInvitedYouSelector = "female"`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Fatalf("expected '%s' in:\n%s", want, src)
+		}
+	}
+}
+
+func TestGoEmitDeclaredRegister(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"plugin.go": `package plugin
+
+// Register is the hook of a plugin host, which must not clash with the generated code
+func Register() {}
+
+func NewBundleResources() {}
+`,
+		"strings.xml": `<resources><string name="app_name">app</string></resources>`,
+	})
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	translations, err := Scan(ModuleOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := translations.Emit(); err != nil {
+		t.Fatal(err)
+	}
+
+	declared := make(map[string]string)
+	for _, fname := range []string{"plugin.go", "strings_gen.go"} {
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, fname), nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, decl := range file.Decls {
+			fun, ok := decl.(*ast.FuncDecl)
+			if !ok || fun.Recv != nil || fun.Name.Name == "init" {
+				continue
+			}
+
+			if other, ok := declared[fun.Name.Name]; ok {
+				t.Fatalf("%s is declared in %s and %s", fun.Name.Name, other, fname)
+			}
+
+			declared[fun.Name.Name] = fname
+		}
+	}
+
+	for _, want := range []string{"RegisterStrings", "NewResourcesFromBundle"} {
+		if declared[want] != "strings_gen.go" {
+			t.Fatalf("expected the generated function %s", want)
+		}
+	}
+}
+
+func TestGoIdentifierClash(t *testing.T) {
+	tests := []struct {
+		name    string
+		strings string
+		want    string
+	}{
+		{"unique", `<string-array name="x" tools:select="a-b,other"><item>a</item><item>b</item></string-array>`, ""},
+		{"selectors", `<string-array name="x" tools:select="a-b,a_b,other"><item>a</item><item>b</item>` +
+			`<item>c</item></string-array>`, "identifier 'XAB' of und.x is not unique"},
+		{"selector type", `<string-array name="x" tools:select="selector,other"><item>a</item><item>b</item>` +
+			`</string-array>`, "identifier 'XSelector' of und.x is not unique"},
+		{"keys", `<string name="a_b">a</string><string name="aB">b</string>`,
+			"identifier 'AB' of und.a_b is not unique, it is also declared by und.aB"},
+		{"fixed", `<string name="func_map">a</string>`, "identifier 'FuncMap' of und.func_map is not unique"},
+		{"decimal", `<plurals name="cats"><item quantity="other">%d cats</item></plurals>` +
+			`<string name="cats_decimal">a</string>`, "identifier 'CatsDecimal' of und.cats_decimal is not unique"},
+		{"ordinal", `<plurals name="nth" tools:ordinal="true"><item quantity="other">%d.</item></plurals>` +
+			`<string name="nth_decimal">a</string>`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeModule(t, map[string]string{
+				"main.go":     "package main\n",
+				"strings.xml": `<resources xmlns:tools="http://schemas.android.com/tools">` + tt.strings + `</resources>`,
+			})
+
+			defer func() {
+				_ = os.RemoveAll(dir)
+			}()
+
+			translations, err := Scan(ModuleOptions{Dir: dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = translations.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected '%s' but got %v", tt.want, err)
+			}
+		})
+	}
+}
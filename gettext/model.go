@@ -0,0 +1,113 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gettext
+
+import (
+	"strings"
+)
+
+const (
+	// FlagFuzzy marks a translation which needs review and is ignored by gettext at runtime
+	FlagFuzzy = "fuzzy"
+	// FlagCFormat marks a msgid and msgstr as printf format strings
+	FlagCFormat = "c-format"
+	// FlagNoCFormat marks a msgid and msgstr as literal strings
+	FlagNoCFormat = "no-c-format"
+
+	// HeaderPluralForms is the header key declaring the amount of plurals and the expression to select them
+	HeaderPluralForms = "Plural-Forms"
+	// HeaderLanguage is the header key declaring the language of the msgstr values
+	HeaderLanguage = "Language"
+)
+
+// Catalog is a PO or POT file. The header is kept separated from the other entries.
+type Catalog struct {
+	// HeaderComments are the comments in front of the header entry
+	HeaderComments []string
+	// Header contains the key-value pairs of the header entry in their original order
+	Header []HeaderField
+	// Entries are all non-header and non-obsolete messages
+	Entries []Entry
+}
+
+// HeaderField is a single key-value line of the header entry, like "Language: de".
+type HeaderField struct {
+	Key   string
+	Value string
+}
+
+// Get returns the value of the first header field with the given key, ignoring the case.
+func (c *Catalog) Get(key string) string {
+	for _, f := range c.Header {
+		if strings.EqualFold(f.Key, key) {
+			return f.Value
+		}
+	}
+
+	return ""
+}
+
+// Set updates the value of the first header field with the given key or appends a new one.
+func (c *Catalog) Set(key, value string) {
+	for i, f := range c.Header {
+		if strings.EqualFold(f.Key, key) {
+			c.Header[i].Value = value
+			return
+		}
+	}
+
+	c.Header = append(c.Header, HeaderField{Key: key, Value: value})
+}
+
+// Entry is a single message.
+type Entry struct {
+	TranslatorComments []string // TranslatorComments are the lines starting with "# "
+	ExtractedComments  []string // ExtractedComments are the lines starting with "#."
+	References         []string // References are the source locations starting with "#:"
+	Flags              []string // Flags are the comma separated flags starting with "#,", like fuzzy or c-format
+	PreviousMsgID      []string // PreviousMsgID are the raw lines starting with "#|"
+	MsgCtxt            string
+	HasMsgCtxt         bool // HasMsgCtxt is required to distinguish between an empty and an absent context
+	MsgID              string
+	MsgIDPlural        string
+	MsgStr             string
+	MsgStrPlural       []string // MsgStrPlural contains msgstr[n] at index n, if MsgIDPlural is not empty
+}
+
+// HasFlag returns true, if the given flag is declared.
+func (e *Entry) HasFlag(flag string) bool {
+	for _, f := range e.Flags {
+		if f == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPlural returns true, if a msgid_plural has been declared.
+func (e *Entry) IsPlural() bool {
+	return e.MsgIDPlural != "" || len(e.MsgStrPlural) > 0
+}
+
+// Key returns the msgctxt, if defined, otherwise the msgid. A key based catalog, like one converted from android
+// resources, uses the context for the resource name and the msgid for the source text.
+func (e *Entry) Key() string {
+	if e.HasMsgCtxt {
+		return e.MsgCtxt
+	}
+
+	return e.MsgID
+}
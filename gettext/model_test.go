@@ -0,0 +1,121 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gettext
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	catalog, err := ReadFile("strings_test.po")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if catalog.Get("plural-forms") != "nplurals=2; plural=(n != 1);" {
+		t.Fatalf("unexpected header %+v", catalog.Header)
+	}
+
+	if len(catalog.HeaderComments) != 3 || len(catalog.Entries) != 8 {
+		t.Fatalf("unexpected catalog %+v", catalog)
+	}
+
+	appName := catalog.Entries[0]
+	if appName.Key() != "app_name" || appName.MsgStr != "LeichteApp" ||
+		!reflect.DeepEqual(appName.TranslatorComments, []string{"the name of the app"}) ||
+		!reflect.DeepEqual(appName.ExtractedComments, []string{"shown in the launcher"}) ||
+		!reflect.DeepEqual(appName.References, []string{"example/example.go:12"}) {
+		t.Fatalf("unexpected entry %+v", appName)
+	}
+
+	if catalog.Entries[1].Key() != "hello_world" {
+		t.Fatalf("expected msgid as key but got %s", catalog.Entries[1].Key())
+	}
+
+	cats := catalog.Entries[3]
+	if !cats.IsPlural() || !cats.HasFlag(FlagCFormat) || len(cats.MsgStrPlural) != 2 {
+		t.Fatalf("unexpected plural %+v", cats)
+	}
+
+	if !catalog.Entries[4].HasFlag(FlagFuzzy) || catalog.Entries[5].MsgStr != "100% fertig" {
+		t.Fatalf("unexpected entries %+v", catalog.Entries[4:6])
+	}
+}
+
+func TestWrite(t *testing.T) {
+	catalog, err := ReadFile("strings_test.po")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog.Entries[1].MsgStr = "multi\nline \"quoted\"\n"
+
+	buf := &bytes.Buffer{}
+	if err := Write(buf, catalog); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog2, err := Read(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(catalog, catalog2) {
+		t.Fatalf("expected\n%+v\nbut got\n%+v\n%s", catalog, catalog2, buf.String())
+	}
+}
+
+func TestPluralForms(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []int // want contains the expected index for n=0...
+	}{
+		{"germanic", "nplurals=2; plural=(n != 1);", []int{1, 0, 1, 1}},
+		{"french", "nplurals=2; plural=n>1;", []int{0, 0, 1, 1}},
+		{"asian", "nplurals=1; plural=0;", []int{0, 0, 0}},
+		{"russian", DefaultPluralForms("ru").String(),
+			[]int{2, 0, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 0, 1}},
+		{"czech", DefaultPluralForms("cs_CZ").String(), []int{2, 0, 1, 1, 1, 2}},
+		{"arabic", DefaultPluralForms("ar").String(), []int{0, 1, 2, 3, 3, 3, 3, 3, 3, 3, 3, 4}},
+		{"not", "nplurals=2; plural=!(n == 1);", []int{1, 0, 1}},
+		{"out of range", "nplurals=2; plural=n;", []int{0, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forms, err := ParsePluralForms(tt.header)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for n, want := range tt.want {
+				if got := forms.Index(n); got != want {
+					t.Errorf("Index(%d) = %d, want %d", n, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePluralFormsInvalid(t *testing.T) {
+	for _, header := range []string{"", "nplurals=2;", "nplurals=x; plural=n;", "nplurals=2; plural=(n != 1;",
+		"nplurals=2; plural=n $ 1;"} {
+		if _, err := ParsePluralForms(header); err == nil {
+			t.Errorf("expected error for '%s'", header)
+		}
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gettext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PluralForms is the parsed Plural-Forms header, like "nplurals=2; plural=(n != 1);". The expression is a C
+// expression of n, which evaluates to the msgstr[n] index.
+type PluralForms struct {
+	NPlurals   int
+	Expression string
+	eval       func(n int) int
+}
+
+// nolint: gochecknoglobals
+var knownPluralForms = map[string]string{
+	"ja":    "nplurals=1; plural=0;",
+	"ko":    "nplurals=1; plural=0;",
+	"zh":    "nplurals=1; plural=0;",
+	"vi":    "nplurals=1; plural=0;",
+	"th":    "nplurals=1; plural=0;",
+	"id":    "nplurals=1; plural=0;",
+	"ms":    "nplurals=1; plural=0;",
+	"en":    "nplurals=2; plural=(n != 1);",
+	"de":    "nplurals=2; plural=(n != 1);",
+	"nl":    "nplurals=2; plural=(n != 1);",
+	"sv":    "nplurals=2; plural=(n != 1);",
+	"da":    "nplurals=2; plural=(n != 1);",
+	"nb":    "nplurals=2; plural=(n != 1);",
+	"nn":    "nplurals=2; plural=(n != 1);",
+	"fi":    "nplurals=2; plural=(n != 1);",
+	"et":    "nplurals=2; plural=(n != 1);",
+	"el":    "nplurals=2; plural=(n != 1);",
+	"hu":    "nplurals=2; plural=(n != 1);",
+	"bg":    "nplurals=2; plural=(n != 1);",
+	"it":    "nplurals=2; plural=(n != 1);",
+	"es":    "nplurals=2; plural=(n != 1);",
+	"pt":    "nplurals=2; plural=(n != 1);",
+	"ca":    "nplurals=2; plural=(n != 1);",
+	"tr":    "nplurals=2; plural=(n != 1);",
+	"he":    "nplurals=2; plural=(n != 1);",
+	"fr":    "nplurals=2; plural=(n > 1);",
+	"pt-BR": "nplurals=2; plural=(n > 1);",
+	"ru":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"uk":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"be":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"sr":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"hr":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"bs":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"pl":    "nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"cs":    "nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;",
+	"sk":    "nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;",
+	"lt":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && (n%100<10 || n%100>=20) ? 1 : 2);",
+	"lv":    "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n != 0 ? 1 : 2);",
+	"ro":    "nplurals=3; plural=(n==1 ? 0 : (n==0 || (n%100 > 0 && n%100 < 20)) ? 1 : 2);",
+	"sl":    "nplurals=4; plural=(n%100==1 ? 0 : n%100==2 ? 1 : n%100==3 || n%100==4 ? 2 : 3);",
+	"ga":    "nplurals=5; plural=(n==1 ? 0 : n==2 ? 1 : n<7 ? 2 : n<11 ? 3 : 4);",
+	"ar":    "nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5);",
+}
+
+// TemplatePluralForms is the placeholder of the Plural-Forms header in a POT file, which has no language yet
+const TemplatePluralForms = "nplurals=INTEGER; plural=EXPRESSION;"
+
+// DefaultPluralForms returns the well known plural forms of the given BCP 47 language, like "de" or "pt-BR". If
+// the language is unknown, the germanic "nplurals=2; plural=(n != 1);" is returned.
+func DefaultPluralForms(lang string) PluralForms {
+	lang = strings.ReplaceAll(lang, "_", "-")
+	candidates := []string{lang}
+
+	if i := strings.Index(lang, "-"); i > 0 {
+		candidates = append(candidates, lang[:i])
+	}
+
+	for _, c := range candidates {
+		if str, ok := knownPluralForms[c]; ok {
+			forms, err := ParsePluralForms(str)
+			if err != nil {
+				panic("assert: invalid builtin plural forms: " + err.Error())
+			}
+
+			return forms
+		}
+	}
+
+	forms, _ := ParsePluralForms(knownPluralForms["en"])
+
+	return forms
+}
+
+// ParsePluralForms parses the value of a Plural-Forms header
+func ParsePluralForms(header string) (PluralForms, error) {
+	res := PluralForms{}
+
+	for _, part := range strings.Split(header, ";") {
+		i := strings.Index(part, "=")
+		if i < 0 {
+			continue
+		}
+
+		key := strings.TrimSpace(part[:i])
+		value := strings.TrimSpace(part[i+1:])
+
+		switch key {
+		case "nplurals":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return res, fmt.Errorf("invalid nplurals '%s'", value)
+			}
+
+			res.NPlurals = n
+		case "plural":
+			eval, err := parseExpression(value)
+			if err != nil {
+				return res, fmt.Errorf("invalid plural expression '%s': %w", value, err)
+			}
+
+			res.Expression = value
+			res.eval = eval
+		}
+	}
+
+	if res.NPlurals == 0 || res.eval == nil {
+		return res, fmt.Errorf("incomplete plural forms '%s'", header)
+	}
+
+	return res, nil
+}
+
+// Index evaluates the plural expression and returns the msgstr index for n. An index which is out of range
+// is clamped to the last form.
+func (p PluralForms) Index(n int) int {
+	if p.eval == nil {
+		return 0
+	}
+
+	i := p.eval(n)
+	if i < 0 || i >= p.NPlurals {
+		return p.NPlurals - 1
+	}
+
+	return i
+}
+
+// String returns the header notation
+func (p PluralForms) String() string {
+	return "nplurals=" + strconv.Itoa(p.NPlurals) + "; plural=" + p.Expression + ";"
+}
+
+// expression is a recursive descent parser for the C subset used by plural expressions. The precedence from low
+// to high is ?:, ||, &&, == !=, < > <= >=, + -, * / %, ! and finally n, numbers and parentheses.
+type expression struct {
+	tokens []string
+	pos    int
+}
+
+func parseExpression(str string) (func(n int) int, error) {
+	tokens, err := tokenize(str)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &expression{tokens: tokens}
+
+	eval, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token '%s'", p.tokens[p.pos])
+	}
+
+	return eval, nil
+}
+
+func tokenize(str string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(str); {
+		c := str[i]
+
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(str) && str[i] >= '0' && str[i] <= '9' {
+				i++
+			}
+
+			tokens = append(tokens, str[start:i])
+		case i+1 < len(str) && contains(operators, str[i:i+2]):
+			tokens = append(tokens, str[i:i+2])
+			i += 2
+		case strings.IndexByte("n?:<>+-*/%!()", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character '%c'", c)
+		}
+	}
+
+	return tokens, nil
+}
+
+func (p *expression) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *expression) expect(token string) error {
+	if p.peek() != token {
+		return fmt.Errorf("expected '%s' but got '%s'", token, p.peek())
+	}
+
+	p.pos++
+
+	return nil
+}
+
+func (p *expression) ternary() (func(n int) int, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek() != "?" {
+		return cond, nil
+	}
+
+	p.pos++
+
+	yes, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+
+	no, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(n int) int {
+		if cond(n) != 0 {
+			return yes(n)
+		}
+
+		return no(n)
+	}, nil
+}
+
+// nolint: gochecknoglobals
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||"}
+
+// nolint: gochecknoglobals
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", ">", "<=", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *expression) binary(level int) (func(n int) int, error) {
+	if level == len(precedence) {
+		return p.unary()
+	}
+
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.peek()
+		if !contains(precedence[level], op) {
+			return left, nil
+		}
+
+		p.pos++
+
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = combine(op, left, right)
+	}
+}
+
+func (p *expression) unary() (func(n int) int, error) {
+	switch tok := p.peek(); {
+	case tok == "!":
+		p.pos++
+
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		return func(n int) int { return boolToInt(operand(n) == 0) }, nil
+	case tok == "(":
+		p.pos++
+
+		inner, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+
+		return inner, p.expect(")")
+	case tok == "n":
+		p.pos++
+		return func(n int) int { return n }, nil
+	case tok != "" && tok[0] >= '0' && tok[0] <= '9':
+		p.pos++
+
+		v, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(int) int { return v }, nil
+	default:
+		return nil, fmt.Errorf("unexpected token '%s'", tok)
+	}
+}
+
+func combine(op string, left, right func(n int) int) func(n int) int {
+	switch op {
+	case "||":
+		return func(n int) int { return boolToInt(left(n) != 0 || right(n) != 0) }
+	case "&&":
+		return func(n int) int { return boolToInt(left(n) != 0 && right(n) != 0) }
+	case "==":
+		return func(n int) int { return boolToInt(left(n) == right(n)) }
+	case "!=":
+		return func(n int) int { return boolToInt(left(n) != right(n)) }
+	case "<":
+		return func(n int) int { return boolToInt(left(n) < right(n)) }
+	case ">":
+		return func(n int) int { return boolToInt(left(n) > right(n)) }
+	case "<=":
+		return func(n int) int { return boolToInt(left(n) <= right(n)) }
+	case ">=":
+		return func(n int) int { return boolToInt(left(n) >= right(n)) }
+	case "+":
+		return func(n int) int { return left(n) + right(n) }
+	case "-":
+		return func(n int) int { return left(n) - right(n) }
+	case "*":
+		return func(n int) int { return left(n) * right(n) }
+	case "/":
+		return func(n int) int { return safeDiv(left(n), right(n), false) }
+	default:
+		return func(n int) int { return safeDiv(left(n), right(n), true) }
+	}
+}
+
+// safeDiv avoids a panic for a division by zero, which is undefined in C anyway.
+func safeDiv(a, b int, mod bool) int {
+	if b == 0 {
+		return 0
+	}
+
+	if mod {
+		return a % b
+	}
+
+	return a / b
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func contains(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,271 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gettext
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// the keyword, which receives continuation lines
+const (
+	kwNone = iota
+	kwMsgCtxt
+	kwMsgID
+	kwMsgIDPlural
+	kwMsgStr
+	kwMsgStrPlural
+)
+
+type parser struct {
+	catalog   Catalog
+	entry     Entry
+	started   bool // started is true, if the entry contains anything
+	hasMsgStr bool // hasMsgStr is true, if the entry is complete and a new keyword starts a new entry
+	hasHeader bool
+	keyword   int
+	plural    int // plural is the index of the last msgstr[n]
+}
+
+// Read parses a gettext PO or POT document
+func Read(reader io.Reader) (Catalog, error) {
+	p := &parser{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+
+		if err := p.line(strings.TrimSpace(scanner.Text())); err != nil {
+			return p.catalog, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return p.catalog, fmt.Errorf("failed to read entire po: %w", err)
+	}
+
+	p.flush()
+
+	return p.catalog, nil
+}
+
+// ReadFile parses a gettext PO or POT file from the file system
+func ReadFile(fname string) (Catalog, error) {
+	file, err := os.Open(fname)
+	if err != nil {
+		return Catalog{}, fmt.Errorf("cannot open '%s':%w", fname, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return Read(file)
+}
+
+func (p *parser) line(line string) error {
+	switch {
+	case line == "":
+		p.flush()
+	case strings.HasPrefix(line, "#~"):
+		// obsolete entries are dropped
+	case strings.HasPrefix(line, "#"):
+		if p.hasMsgStr {
+			p.flush()
+		}
+
+		p.comment(line)
+	case strings.HasPrefix(line, `"`):
+		str, err := unquote(line)
+		if err != nil {
+			return err
+		}
+
+		p.appendTo(str)
+	default:
+		return p.keywordLine(line)
+	}
+
+	return nil
+}
+
+func (p *parser) comment(line string) {
+	p.started = true
+
+	switch {
+	case strings.HasPrefix(line, "#."):
+		p.entry.ExtractedComments = append(p.entry.ExtractedComments, strings.TrimSpace(line[2:]))
+	case strings.HasPrefix(line, "#:"):
+		p.entry.References = append(p.entry.References, strings.Fields(line[2:])...)
+	case strings.HasPrefix(line, "#,"):
+		for _, flag := range strings.Split(line[2:], ",") {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				p.entry.Flags = append(p.entry.Flags, flag)
+			}
+		}
+	case strings.HasPrefix(line, "#|"):
+		p.entry.PreviousMsgID = append(p.entry.PreviousMsgID, strings.TrimSpace(line[2:]))
+	default:
+		text := strings.TrimPrefix(line[1:], " ")
+		p.entry.TranslatorComments = append(p.entry.TranslatorComments, text)
+	}
+}
+
+func (p *parser) keywordLine(line string) error {
+	sep := strings.IndexAny(line, " \t")
+	if sep < 0 {
+		return fmt.Errorf("expected keyword and string but got '%s'", line)
+	}
+
+	keyword := line[:sep]
+
+	str, err := unquote(strings.TrimSpace(line[sep:]))
+	if err != nil {
+		return err
+	}
+
+	if p.hasMsgStr && (keyword == "msgctxt" || keyword == "msgid") {
+		p.flush()
+	}
+
+	p.started = true
+
+	switch {
+	case keyword == "msgctxt":
+		p.keyword = kwMsgCtxt
+		p.entry.MsgCtxt = str
+		p.entry.HasMsgCtxt = true
+	case keyword == "msgid":
+		p.keyword = kwMsgID
+		p.entry.MsgID = str
+	case keyword == "msgid_plural":
+		p.keyword = kwMsgIDPlural
+		p.entry.MsgIDPlural = str
+	case keyword == "msgstr":
+		p.keyword = kwMsgStr
+		p.entry.MsgStr = str
+		p.hasMsgStr = true
+	case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+		n, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid plural index '%s'", keyword)
+		}
+
+		for len(p.entry.MsgStrPlural) <= n {
+			p.entry.MsgStrPlural = append(p.entry.MsgStrPlural, "")
+		}
+
+		p.keyword = kwMsgStrPlural
+		p.plural = n
+		p.entry.MsgStrPlural[n] = str
+		p.hasMsgStr = true
+	default:
+		return fmt.Errorf("unknown keyword '%s'", keyword)
+	}
+
+	return nil
+}
+
+func (p *parser) appendTo(str string) {
+	switch p.keyword {
+	case kwMsgCtxt:
+		p.entry.MsgCtxt += str
+	case kwMsgID:
+		p.entry.MsgID += str
+	case kwMsgIDPlural:
+		p.entry.MsgIDPlural += str
+	case kwMsgStr:
+		p.entry.MsgStr += str
+	case kwMsgStrPlural:
+		p.entry.MsgStrPlural[p.plural] += str
+	}
+}
+
+// flush finishes the current entry. The first entry without msgid and msgctxt is the header.
+func (p *parser) flush() {
+	if !p.started {
+		return
+	}
+
+	if !p.hasHeader && p.entry.MsgID == "" && !p.entry.HasMsgCtxt {
+		p.hasHeader = true
+		p.catalog.HeaderComments = p.entry.TranslatorComments
+
+		for _, line := range strings.Split(p.entry.MsgStr, "\n") {
+			if i := strings.Index(line, ":"); i > 0 {
+				p.catalog.Header = append(p.catalog.Header, HeaderField{
+					Key:   strings.TrimSpace(line[:i]),
+					Value: strings.TrimSpace(line[i+1:]),
+				})
+			}
+		}
+	} else {
+		p.catalog.Entries = append(p.catalog.Entries, p.entry)
+	}
+
+	p.entry = Entry{}
+	p.started = false
+	p.hasMsgStr = false
+	p.keyword = kwNone
+}
+
+// unquote parses a C like string literal, as used by gettext.
+func unquote(str string) (string, error) {
+	if len(str) < 2 || str[0] != '"' || str[len(str)-1] != '"' {
+		return "", fmt.Errorf("expected quoted string but got '%s'", str)
+	}
+
+	str = str[1 : len(str)-1]
+	if strings.IndexByte(str, '\\') < 0 {
+		return str, nil
+	}
+
+	sb := &strings.Builder{}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c != '\\' || i+1 == len(str) {
+			sb.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch str[i] {
+		case 'n':
+			sb.WriteByte('\n')
+		case 't':
+			sb.WriteByte('\t')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'a':
+			sb.WriteByte('\a')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'v':
+			sb.WriteByte('\v')
+		default:
+			sb.WriteByte(str[i])
+		}
+	}
+
+	return sb.String(), nil
+}
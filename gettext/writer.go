@@ -0,0 +1,143 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gettext
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Write serializes the catalog as a PO or POT document. Entries are written in their given order.
+func Write(writer io.Writer, catalog Catalog) error {
+	w := bufio.NewWriter(writer)
+
+	for _, c := range catalog.HeaderComments {
+		writeComment(w, "#", c)
+	}
+
+	sb := &strings.Builder{}
+	for _, f := range catalog.Header {
+		sb.WriteString(f.Key + ": " + f.Value + "\n")
+	}
+
+	writeString(w, "msgid", "")
+	writeString(w, "msgstr", sb.String())
+
+	for _, e := range catalog.Entries {
+		_, _ = w.WriteString("\n")
+
+		for _, c := range e.TranslatorComments {
+			writeComment(w, "#", c)
+		}
+
+		for _, c := range e.ExtractedComments {
+			writeComment(w, "#.", c)
+		}
+
+		if len(e.References) > 0 {
+			writeComment(w, "#:", strings.Join(e.References, " "))
+		}
+
+		if len(e.Flags) > 0 {
+			writeComment(w, "#,", strings.Join(e.Flags, ", "))
+		}
+
+		for _, c := range e.PreviousMsgID {
+			writeComment(w, "#|", c)
+		}
+
+		if e.HasMsgCtxt {
+			writeString(w, "msgctxt", e.MsgCtxt)
+		}
+
+		writeString(w, "msgid", e.MsgID)
+
+		if e.IsPlural() {
+			writeString(w, "msgid_plural", e.MsgIDPlural)
+
+			for i, str := range e.MsgStrPlural {
+				writeString(w, "msgstr["+strconv.Itoa(i)+"]", str)
+			}
+		} else {
+			writeString(w, "msgstr", e.MsgStr)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write po: %w", err)
+	}
+
+	return nil
+}
+
+// WriteFile serializes the catalog into a PO or POT file
+func WriteFile(fname string, catalog Catalog) error {
+	file, err := os.Create(fname)
+	if err != nil {
+		return fmt.Errorf("cannot create '%s':%w", fname, err)
+	}
+
+	err = Write(file, catalog)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("cannot close '%s':%w", fname, err)
+	}
+
+	return nil
+}
+
+func writeComment(w *bufio.Writer, prefix, text string) {
+	if text == "" {
+		_, _ = w.WriteString(prefix + "\n")
+		return
+	}
+
+	_, _ = w.WriteString(prefix + " " + text + "\n")
+}
+
+// writeString writes the keyword and the quoted string. Multiline strings are split after each newline, as
+// xgettext does.
+func writeString(w *bufio.Writer, keyword, str string) {
+	lines := strings.SplitAfter(str, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) <= 1 {
+		_, _ = w.WriteString(keyword + " " + quote(str) + "\n")
+		return
+	}
+
+	_, _ = w.WriteString(keyword + " \"\"\n")
+	for _, line := range lines {
+		_, _ = w.WriteString(quote(line) + "\n")
+	}
+}
+
+// quote is the inverse of unquote
+func quote(str string) string {
+	return `"` + quoteEscaper.Replace(str) + `"`
+}
+
+// nolint: gochecknoglobals
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
@@ -0,0 +1,107 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpi18n negotiates the locale of http requests and provides the matching resources by the request context.
+package httpi18n
+
+import (
+	"context"
+	"github.com/golangee/i18n"
+	"golang.org/x/text/language"
+	"net/http"
+)
+
+type ctxKey struct{}
+
+// Options configures the negotiation of the middleware
+type Options struct {
+	// Bundle to match the locales against. If nil, the default bundle is used.
+	Bundle *i18n.Bundle
+	// Cookie is the optional name of a cookie, whose value overrides the Accept-Language header
+	Cookie string
+	// Query is the optional name of a query parameter, whose value overrides the cookie and the Accept-Language header
+	Query string
+}
+
+// Middleware returns a handler, which matches the wanted locales of each request and stores the resources in the
+// request context, see also FromContext. The query parameter has precedence over the cookie and the cookie over the
+// Accept-Language header. The response gets the according Content-Language and Vary headers. If the bundle
+// has no locales, the request is passed through unchanged.
+func Middleware(opts Options) func(http.Handler) http.Handler {
+	bundle := opts.Bundle
+	if bundle == nil {
+		bundle = i18n.DefaultBundle()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Language")
+
+			if opts.Cookie != "" {
+				w.Header().Add("Vary", "Cookie")
+			}
+
+			res, err := bundle.MatchStrict(language.No, Wanted(r, opts)...)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if tag := res.Locale(); tag != language.Und.String() {
+				w.Header().Set("Content-Language", tag)
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithResources(r.Context(), res)))
+		})
+	}
+}
+
+// Wanted returns the locales of the request in their order of preference. An invalid Accept-Language header
+// is ignored.
+func Wanted(r *http.Request, opts Options) []string {
+	var res []string
+
+	if opts.Query != "" {
+		if locale := r.URL.Query().Get(opts.Query); locale != "" {
+			res = append(res, locale)
+		}
+	}
+
+	if opts.Cookie != "" {
+		if cookie, err := r.Cookie(opts.Cookie); err == nil && cookie.Value != "" {
+			res = append(res, cookie.Value)
+		}
+	}
+
+	// the tags are already sorted by their q-values
+	tags, _, _ := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	for _, tag := range tags {
+		res = append(res, tag.String())
+	}
+
+	return res
+}
+
+// WithResources returns a new context which contains the given resources
+func WithResources(ctx context.Context, res *i18n.Resources) context.Context {
+	return context.WithValue(ctx, ctxKey{}, res)
+}
+
+// FromContext returns the resources of the context or nil, if the context has not been passed through the
+// middleware.
+func FromContext(ctx context.Context) *i18n.Resources {
+	res, _ := ctx.Value(ctxKey{}).(*i18n.Resources)
+
+	return res
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpi18n
+
+import (
+	"github.com/golangee/i18n"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	bundle := i18n.NewBundle()
+	bundle.ImportValue(i18n.NewText("und", "hello", "Hello"))
+	bundle.ImportValue(i18n.NewText("de", "hello", "Hallo"))
+	bundle.ImportValue(i18n.NewText("fr", "hello", "Bonjour"))
+
+	handler := Middleware(Options{Bundle: bundle, Cookie: "lang", Query: "lang"})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			str, err := FromContext(r.Context()).Text("hello")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _ = w.Write([]byte(str))
+		}))
+
+	tests := []struct {
+		name     string
+		url      string
+		accept   string
+		cookie   string
+		want     string
+		language string
+	}{
+		{"accept", "/", "fr-CH, de;q=0.9", "", "Bonjour", "fr"},
+		{"q-values", "/", "de;q=0.5, fr;q=0.8", "", "Bonjour", "fr"},
+		{"none", "/", "", "", "Hello", ""},
+		{"invalid", "/", "%%%", "", "Hello", ""},
+		{"cookie", "/", "fr", "de", "Hallo", "de"},
+		{"query", "/?lang=fr", "de", "de", "Bonjour", "fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("Accept-Language", tt.accept)
+
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if body := rec.Body.String(); body != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, body)
+			}
+
+			if lang := rec.Header().Get("Content-Language"); lang != tt.language {
+				t.Fatalf("expected Content-Language '%s' but got '%s'", tt.language, lang)
+			}
+
+			if vary := rec.Header().Values("Vary"); !reflect.DeepEqual(vary, []string{"Accept-Language", "Cookie"}) {
+				t.Fatalf("unexpected Vary %v", vary)
+			}
+		})
+	}
+}
+
+func TestMiddlewareUnconfigured(t *testing.T) {
+	called := false
+	handler := Middleware(Options{Bundle: i18n.NewBundle()})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+
+			if FromContext(r.Context()) != nil {
+				t.Fatal("expected no resources")
+			}
+		}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !called {
+		t.Fatal("expected pass through")
+	}
+}
@@ -0,0 +1,473 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package icu parses the ICU MessageFormat syntax, like "{count, plural, one {# cat} other {# cats}}".
+package icu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// TypePlural is the cardinal plural argument type
+	TypePlural = "plural"
+	// TypeSelectOrdinal is the ordinal plural argument type
+	TypeSelectOrdinal = "selectordinal"
+	// TypeSelect is the select argument type, e.g. for grammatical gender
+	TypeSelect = "select"
+	// TypeNumber is the number argument type, like {n, number} or {n, number, integer}
+	TypeNumber = "number"
+	// TypeDate is the date argument type, like {d, date, short}
+	TypeDate = "date"
+	// TypeTime is the time argument type, like {d, time, short}
+	TypeTime = "time"
+	// TypeDuration is the duration argument type, like {d, duration} or {d, duration, relative}
+	TypeDuration = "duration"
+)
+
+// Node is a part of a parsed message, which is one of *Text, *Argument, *Pound, *Plural or *Select.
+type Node interface {
+	node()
+}
+
+// Message is a sequence of nodes
+type Message []Node
+
+// Text is a literal and already unquoted text
+type Text struct {
+	Value string
+}
+
+// Argument is a simple {name} or a formatted {name, type, style} argument, like {n, number} or
+// {d, date, short}.
+type Argument struct {
+	Name  string
+	Type  string // Type is empty for a simple argument
+	Style string
+}
+
+// Pound is the # within a plural case, which is replaced by the formatted number (minus the offset).
+type Pound struct {
+}
+
+// Plural is a {name, plural, ...} or {name, selectordinal, ...} argument
+type Plural struct {
+	Name    string
+	Ordinal bool
+	Offset  int
+	Cases   []Case // Cases contains exact matches like =0 and categories like one or other
+}
+
+// Select is a {name, select, ...} argument
+type Select struct {
+	Name  string
+	Cases []Case
+}
+
+// Case is a selector with its message, like one {# cat}
+type Case struct {
+	Key     string
+	Message Message
+}
+
+func (*Text) node()     {}
+func (*Argument) node() {}
+func (*Pound) node()    {}
+func (*Plural) node()   {}
+func (*Select) node()   {}
+
+// Case returns the message for the given key or nil, which is also an empty message.
+func (p *Plural) Case(key string) Message {
+	return findCase(p.Cases, key)
+}
+
+// Case returns the message for the given key or nil, which is also an empty message.
+func (s *Select) Case(key string) Message {
+	return findCase(s.Cases, key)
+}
+
+func findCase(cases []Case, key string) Message {
+	for _, c := range cases {
+		if c.Key == key {
+			return c.Message
+		}
+	}
+
+	return nil
+}
+
+// Parse parses the given message pattern
+func Parse(pattern string) (Message, error) {
+	p := &parser{src: []rune(pattern)}
+
+	msg, err := p.message(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected '%c'", p.src[p.pos])
+	}
+
+	return msg, nil
+}
+
+type parser struct {
+	src []rune
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// message parses until the end or an unmatched }. Within a plural, # is a Pound.
+func (p *parser) message(inPlural bool) (Message, error) {
+	var msg Message
+
+	sb := &strings.Builder{}
+	flush := func() {
+		if sb.Len() > 0 {
+			msg = append(msg, &Text{Value: sb.String()})
+			sb.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+
+		switch {
+		case c == '}':
+			flush()
+			return msg, nil
+		case c == '{':
+			flush()
+
+			node, err := p.argument()
+			if err != nil {
+				return nil, err
+			}
+
+			msg = append(msg, node)
+		case c == '#' && inPlural:
+			flush()
+
+			msg = append(msg, &Pound{})
+			p.pos++
+		case c == '\'':
+			p.quoted(sb, inPlural)
+		default:
+			sb.WriteRune(c)
+			p.pos++
+		}
+	}
+
+	flush()
+
+	return msg, nil
+}
+
+// quoted applies the apostrophe rules: a doubled apostrophe is a single one and an apostrophe in front of a syntax char starts
+// a literal section until the next single apostrophe. Any other apostrophe is just a literal.
+func (p *parser) quoted(sb *strings.Builder, inPlural bool) {
+	next := rune(0)
+	if p.pos+1 < len(p.src) {
+		next = p.src[p.pos+1]
+	}
+
+	switch {
+	case next == '\'':
+		sb.WriteRune('\'')
+		p.pos += 2
+	case next == '{' || next == '}' || next == '|' || (next == '#' && inPlural):
+		p.pos++
+		for p.pos < len(p.src) {
+			if p.src[p.pos] == '\'' {
+				if p.pos+1 < len(p.src) && p.src[p.pos+1] == '\'' {
+					sb.WriteRune('\'')
+					p.pos += 2
+
+					continue
+				}
+
+				p.pos++
+
+				return
+			}
+
+			sb.WriteRune(p.src[p.pos])
+			p.pos++
+		}
+	default:
+		sb.WriteRune('\'')
+		p.pos++
+	}
+}
+
+// argument parses {name}, {name, type}, {name, type, style} or {name, plural|select|selectordinal, cases}
+func (p *parser) argument() (Node, error) {
+	p.pos++ // {
+
+	name := p.identifier()
+	if name == "" {
+		return nil, p.errorf("expected argument name")
+	}
+
+	p.skipSpace()
+
+	if p.consume('}') {
+		return &Argument{Name: name}, nil
+	}
+
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' or '}' after argument '%s'", name)
+	}
+
+	p.skipSpace()
+	typ := p.identifier()
+	p.skipSpace()
+
+	switch typ {
+	case TypePlural, TypeSelectOrdinal:
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' after %s", typ)
+		}
+
+		pl := &Plural{Name: name, Ordinal: typ == TypeSelectOrdinal}
+
+		p.skipSpace()
+
+		if p.hasPrefix("offset:") {
+			p.pos += len("offset:")
+			p.skipSpace()
+
+			start := p.pos
+			for p.pos < len(p.src) && unicode.IsDigit(p.src[p.pos]) {
+				p.pos++
+			}
+
+			offset, err := strconv.Atoi(string(p.src[start:p.pos]))
+			if err != nil {
+				return nil, p.errorf("invalid offset: %v", err)
+			}
+
+			pl.Offset = offset
+		}
+
+		cases, err := p.cases(true)
+		if err != nil {
+			return nil, err
+		}
+
+		pl.Cases = cases
+
+		return pl, nil
+	case TypeSelect:
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' after select")
+		}
+
+		cases, err := p.cases(false)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Select{Name: name, Cases: cases}, nil
+	case "":
+		return nil, p.errorf("expected type of argument '%s'", name)
+	default:
+		arg := &Argument{Name: name, Type: typ}
+		if p.consume(',') {
+			start := p.pos
+			depth := 0
+
+			for p.pos < len(p.src) && (p.src[p.pos] != '}' || depth > 0) {
+				switch p.src[p.pos] {
+				case '{':
+					depth++
+				case '}':
+					depth--
+				}
+
+				p.pos++
+			}
+
+			arg.Style = strings.TrimSpace(string(p.src[start:p.pos]))
+		}
+
+		if !p.consume('}') {
+			return nil, p.errorf("expected '}' after argument '%s'", name)
+		}
+
+		return arg, nil
+	}
+}
+
+// cases parses key {message} pairs until the closing }. An other case is mandatory.
+func (p *parser) cases(inPlural bool) ([]Case, error) {
+	var cases []Case
+
+	for {
+		p.skipSpace()
+
+		if p.consume('}') {
+			break
+		}
+
+		key := p.selector()
+		if key == "" {
+			return nil, p.errorf("expected case selector")
+		}
+
+		p.skipSpace()
+
+		if !p.consume('{') {
+			return nil, p.errorf("expected '{' after case '%s'", key)
+		}
+
+		msg, err := p.message(inPlural)
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.consume('}') {
+			return nil, p.errorf("unterminated case '%s'", key)
+		}
+
+		cases = append(cases, Case{Key: key, Message: msg})
+	}
+
+	hasOther := false
+	for _, c := range cases {
+		hasOther = hasOther || c.Key == "other"
+	}
+
+	if !hasOther {
+		return nil, p.errorf("missing 'other' case")
+	}
+
+	return cases, nil
+}
+
+func (p *parser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if !(unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-') {
+			break
+		}
+
+		p.pos++
+	}
+
+	return string(p.src[start:p.pos])
+}
+
+// selector is an identifier or an exact match like =0
+func (p *parser) selector() string {
+	if p.consume('=') {
+		start := p.pos
+		for p.pos < len(p.src) && (unicode.IsDigit(p.src[p.pos]) || p.src[p.pos] == '.' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+
+		if start == p.pos {
+			return ""
+		}
+
+		return "=" + string(p.src[start:p.pos])
+	}
+
+	return p.identifier()
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) consume(c rune) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(p.src[p.pos:]), prefix)
+}
+
+// ArgumentInfo describes how an argument is used within a message
+type ArgumentInfo struct {
+	Name  string
+	Type  string // Type is the first declared type, like plural, select or number. It is empty for simple arguments.
+	Style string // Style belongs to the first declared type, like integer for {n, number, integer}
+}
+
+// Arguments returns all referenced arguments in order of their first appearance, including nested cases.
+func (m Message) Arguments() []ArgumentInfo {
+	var res []ArgumentInfo
+
+	add := func(name, typ, style string) {
+		for i := range res {
+			if res[i].Name == name {
+				if res[i].Type == "" {
+					res[i].Type = typ
+					res[i].Style = style
+				}
+
+				return
+			}
+		}
+
+		res = append(res, ArgumentInfo{Name: name, Type: typ, Style: style})
+	}
+
+	var walk func(msg Message)
+	walk = func(msg Message) {
+		for _, n := range msg {
+			switch t := n.(type) {
+			case *Argument:
+				add(t.Name, t.Type, t.Style)
+			case *Plural:
+				typ := TypePlural
+				if t.Ordinal {
+					typ = TypeSelectOrdinal
+				}
+
+				add(t.Name, typ, "")
+
+				for _, c := range t.Cases {
+					walk(c.Message)
+				}
+			case *Select:
+				add(t.Name, TypeSelect, "")
+
+				for _, c := range t.Cases {
+					walk(c.Message)
+				}
+			}
+		}
+	}
+
+	walk(m)
+
+	return res
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package icu
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    Message
+	}{
+		{"", nil},
+		{"hello", Message{&Text{"hello"}}},
+		{"hello {name}!", Message{&Text{"hello "}, &Argument{Name: "name"}, &Text{"!"}}},
+		{"{n, number}", Message{&Argument{Name: "n", Type: "number"}}},
+		{"{d, date, short}", Message{&Argument{Name: "d", Type: "date", Style: "short"}}},
+		{"it''s '{literal}' # '#'", Message{&Text{"it's {literal} # '#'"}}},
+		{"{count, plural, =0 {none} one {# cat} other {# cats}}", Message{&Plural{
+			Name: "count",
+			Cases: []Case{
+				{Key: "=0", Message: Message{&Text{"none"}}},
+				{Key: "one", Message: Message{&Pound{}, &Text{" cat"}}},
+				{Key: "other", Message: Message{&Pound{}, &Text{" cats"}}},
+			},
+		}}},
+		{"{n, selectordinal, offset:1 other {#.}}", Message{&Plural{
+			Name:    "n",
+			Ordinal: true,
+			Offset:  1,
+			Cases:   []Case{{Key: "other", Message: Message{&Pound{}, &Text{"."}}}},
+		}}},
+		{"{g, select, female {{n, plural, other {she has #}}} other {{x}}}", Message{&Select{
+			Name: "g",
+			Cases: []Case{
+				{Key: "female", Message: Message{&Plural{
+					Name:  "n",
+					Cases: []Case{{Key: "other", Message: Message{&Text{"she has "}, &Pound{}}}},
+				}}},
+				{Key: "other", Message: Message{&Argument{Name: "x"}}},
+			},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, err := Parse(tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %#v but got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []string{
+		"{",
+		"}",
+		"{}",
+		"{name",
+		"{name, }",
+		"{n, plural, one {x}}",
+		"{n, plural, other {x}",
+		"{n, select, other x}",
+		"{n, plural, offset:x other {x}}",
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse(tt); err == nil {
+			t.Fatalf("expected error for '%s'", tt)
+		}
+	}
+}
+
+func TestArguments(t *testing.T) {
+	msg, err := Parse("{a} {b, select, x {{c, plural, other {{a, number}}}} other {}} {c}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ArgumentInfo{{Name: "a", Type: "number"}, {Name: "b", Type: TypeSelect}, {Name: "c", Type: TypePlural}}
+	if got := msg.Arguments(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, got)
+	}
+
+	msg, err = Parse("{n} {n, number, integer} {n, number, percent}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []ArgumentInfo{{Name: "n", Type: TypeNumber, Style: "integer"}}
+	if got := msg.Arguments(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, got)
+	}
+}
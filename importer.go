@@ -38,7 +38,8 @@ type Importer interface {
 }
 
 // An AndroidImporter supports the android strings xml format with simple strings, interpolation, indices, plurals
-// and arrays. However, references and indices with more than 9 not.
+// and arrays. However, references and indices with more than 9 not. Java directives, which go cannot format, like
+// %,d or %tY, fail the import with an android.ErrUnsupportedDirective.
 type AndroidImporter struct {
 }
 
@@ -49,64 +50,171 @@ func (a AndroidImporter) Import(dst *Resources, src io.Reader) error {
 		return fmt.Errorf("failed to import android resources: %w", err)
 	}
 
-	importAndroid(dst, aRes)
+	if err := importAndroid(dst, aRes); err != nil {
+		return fmt.Errorf("failed to import android resources: %w", err)
+	}
 
 	return nil
 }
 
-// importAndroid copies and converts the given android resources into our i18n resources.
-func importAndroid(dst *Resources, src android.Resources) {
-	dst.mutex.Lock()
-	defer dst.mutex.Unlock()
-
+// importAndroid copies and converts the given android resources into our i18n resources. Strings with the icu
+// format become ICU MessageFormat values and the list style of string arrays must be known. Any invalid value
+// fails the import before anything is published.
+func importAndroid(dst *Resources, src android.Resources) error {
 	locale := dst.tag.String()
+	values := make(map[string]Value)
+
 	for _, str := range src.Strings {
-		dst.values[str.Name] = simpleValue{
+		if str.Format == android.FormatICU {
+			val, err := NewMessageText(locale, str.Name, android.Unescape(str.Text))
+			if err != nil {
+				return err
+			}
+
+			msg := val.(messageFormatValue)
+			msg.origin = originOf(src, str.Pos)
+			values[str.Name] = msg
+
+			continue
+		}
+
+		text, err := android.Decode(str.Text)
+		if err != nil {
+			return fmt.Errorf("invalid string '%s': %w", str.Name, err)
+		}
+
+		values[str.Name] = simpleValue{
 			Id:     str.Name,
 			locale: locale,
-			String: android.Decode(str.Text),
+			String: text,
+			origin: originOf(src, str.Pos),
 		}
 	}
 
 	for _, pl := range src.Plurals {
 		val := pluralValue{
-			Id:     pl.Name,
-			tag:    dst.tag,
-			locale: locale,
+			Id:      pl.Name,
+			tag:     dst.tag,
+			locale:  locale,
+			ordinal: pl.Ordinal,
+			origin:  originOf(src, pl.Pos),
 		}
 
 		for _, item := range pl.Items {
+			text, err := android.Decode(item.Text)
+			if err != nil {
+				return fmt.Errorf("invalid plurals '%s': %w", pl.Name, err)
+			}
+
 			switch strings.ToLower(item.Quantity) {
 			case zero:
-				val.zero = android.Decode(item.Text)
+				val.zero = text
 			case one:
-				val.one = android.Decode(item.Text)
+				val.one = text
 			case two:
-				val.two = android.Decode(item.Text)
+				val.two = text
 			case few:
-				val.few = android.Decode(item.Text)
+				val.few = text
 			case many:
-				val.many = android.Decode(item.Text)
+				val.many = text
 			case other:
 				fallthrough
 			default:
-				val.other = android.Decode(item.Text)
+				val.other = text
 			}
 		}
 
-		dst.values[pl.Name] = val
+		values[pl.Name] = val
 	}
 
 	for _, arr := range src.StringArrays {
+		if err := validateStringArray(arr); err != nil {
+			return fmt.Errorf("invalid string-array '%s': %w", arr.Name, err)
+		}
+
 		tmp := make([]string, 0, len(arr.Items))
 		for _, s := range arr.Items {
-			tmp = append(tmp, android.Decode(s))
+			text, err := android.Decode(s)
+			if err != nil {
+				return fmt.Errorf("invalid string-array '%s': %w", arr.Name, err)
+			}
+
+			tmp = append(tmp, text)
 		}
 
-		dst.values[arr.Name] = arrayValue{
+		if arr.Select != "" {
+			values[arr.Name] = importAndroidSelect(locale, src, arr, tmp)
+			continue
+		}
+
+		values[arr.Name] = arrayValue{
 			Id:      arr.Name,
 			locale:  locale,
 			Strings: tmp,
+			list:    arr.List,
+			origin:  originOf(src, arr.Pos),
 		}
 	}
+
+	dst.lock()
+	defer dst.unlock()
+
+	for key, value := range values {
+		dst.values[key] = value
+	}
+
+	return nil
+}
+
+// validateStringArray checks the list style or that the selectors of a select are unique and match the items
+func validateStringArray(arr android.StringArray) error {
+	if arr.List != "" && arr.Select != "" {
+		return fmt.Errorf("a list cannot be a select")
+	}
+
+	if arr.List != "" {
+		if _, err := parseListStyle(arr.List); err != nil {
+			return err
+		}
+	}
+
+	if arr.Select == "" {
+		return nil
+	}
+
+	selectors := strings.Split(arr.Select, ",")
+	if len(selectors) != len(arr.Items) {
+		return fmt.Errorf("%d selectors but %d items", len(selectors), len(arr.Items))
+	}
+
+	unique := make(map[string]bool, len(selectors))
+	for _, selector := range selectors {
+		selector = strings.TrimSpace(selector)
+		if selector == "" || unique[selector] {
+			return fmt.Errorf("empty or duplicate selector '%s'", selector)
+		}
+
+		unique[selector] = true
+	}
+
+	return nil
+}
+
+// importAndroidSelect converts a string-array with the tools:select attribute, whose selectors are already
+// validated and whose items are already decoded
+func importAndroidSelect(locale string, src android.Resources, arr android.StringArray, items []string) selectValue {
+	val := NewSelectText(locale, arr.Name)
+	for i, selector := range strings.Split(arr.Select, ",") {
+		val = val.Case(strings.TrimSpace(selector), items[i])
+	}
+
+	res := val.(selectValue)
+	res.origin = originOf(src, arr.Pos)
+
+	return res
+}
+
+// originOf returns the origin of an element of the android resources
+func originOf(src android.Resources, pos android.Position) Origin {
+	return Origin{File: src.File, Line: pos.Line, Column: pos.Column}
 }
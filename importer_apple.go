@@ -0,0 +1,91 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/apple"
+	"io"
+)
+
+// An AppleStringsImporter supports the apple .strings format. Each pair becomes a simple text and keys like
+// "name[0]", "name[1]" are collected into a text array. The comment in front of a pair becomes its note.
+// Placeholders like %@ or %1$@ are converted into %s or %[1]s.
+type AppleStringsImporter struct {
+}
+
+// Import tries to parse the src bytes and imports that into the given resources.
+func (a AppleStringsImporter) Import(dst *Resources, src io.Reader) error {
+	strs, err := apple.ReadStrings(src)
+	if err != nil {
+		return fmt.Errorf("failed to import apple strings: %w", err)
+	}
+
+	dst.lock()
+	defer dst.unlock()
+
+	arrays := arrayItems{}
+
+	for _, str := range strs {
+		if name, idx, ok := splitArrayKey(str.Key); ok {
+			arrays.add(name, idx, apple.Decode(str.Value))
+			continue
+		}
+
+		dst.values[str.Key] = simpleValue{
+			Id:     str.Key,
+			locale: dst.tag.String(),
+			String: apple.Decode(str.Value),
+			note:   str.Comment,
+		}
+	}
+
+	arrays.importInto(dst)
+
+	return nil
+}
+
+// An AppleStringsDictImporter supports the apple .stringsdict format. Each NSStringPluralRuleType variable becomes
+// a plural, where the NSStringLocalizedFormatKey is applied to each category.
+type AppleStringsDictImporter struct {
+}
+
+// Import tries to parse the src bytes and imports that into the given resources.
+func (a AppleStringsDictImporter) Import(dst *Resources, src io.Reader) error {
+	plurals, err := apple.ReadStringsDict(src)
+	if err != nil {
+		return fmt.Errorf("failed to import apple stringsdict: %w", err)
+	}
+
+	dst.lock()
+	defer dst.unlock()
+
+	for _, pl := range plurals {
+		val := pluralValue{
+			Id:     pl.Key,
+			tag:    dst.tag,
+			locale: dst.tag.String(),
+		}
+
+		for category, text := range pl.Texts() {
+			val.set(category, apple.Decode(text))
+		}
+
+		dst.values[pl.Key] = val
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func importTestFile(t *testing.T, importer Importer, locale string, fname string) {
+	file, err := os.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	err = Import(importer, locale, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAppleImporter(t *testing.T) {
+	setup()
+	importTestFile(t, AppleStringsImporter{}, "de", "apple/Localizable_test.strings")
+	importTestFile(t, AppleStringsDictImporter{}, "de", "apple/Localizable_test.stringsdict")
+
+	res := From("de")
+	str, err := res.Text("x_runs_around_Y_and_sings_z", "Nick", "Baum", "laut")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "Nick läuft um den Baum und singt laut"; str != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, str)
+	}
+
+	str, err = res.QuantityText("x_has_y_cats", 1, "Nick", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "Nick hat 1 Katze"; str != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, str)
+	}
+
+	arr, err := res.TextArray("selector_details_array")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"erste Zeile", "zweite Zeile"}; !reflect.DeepEqual(arr, expected) {
+		t.Fatalf("expected %v but got %v", expected, arr)
+	}
+
+	// the export must be importable again without any changes
+	strs := &bytes.Buffer{}
+	if err := Export(AppleStringsExporter{}, "de", strs); err != nil {
+		t.Fatal(err)
+	}
+
+	dict := &bytes.Buffer{}
+	if err := Export(AppleStringsDictExporter{}, "de", dict); err != nil {
+		t.Fatal(err)
+	}
+
+	res2 := newResources(res.tag)
+	if err := (AppleStringsImporter{}).Import(res2, strs); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (AppleStringsDictImporter{}).Import(res2, dict); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range res.Keys() {
+		if !reflect.DeepEqual(res2.Value(key), res.Value(key)) {
+			t.Fatalf("expected %+v but got %+v", res.Value(key), res2.Value(key))
+		}
+	}
+}
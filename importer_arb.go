@@ -0,0 +1,276 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/arb"
+	"github.com/golangee/i18n/icu"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// An ARBImporter supports the flutter Application Resource Bundle format, whose values use the ICU MessageFormat.
+// The description of the @key attribute becomes the note of a value. Named placeholders are mapped onto
+// positional arguments: first the declared placeholders in their order and then the undeclared ones in order of
+// their first appearance. The placeholder type determines the verb, so int becomes %[n]d, double and num become
+// %[n]f and anything else %[n]s.
+//
+// A single top level plural or selectordinal becomes a plural and a select becomes a select value, whose texts
+// consist of the text in front, the case and the text behind. Any other message becomes an ICU MessageFormat value
+// instead, so that no case is lost: nested or multiple plurals and selects, plurals with an offset and plurals with
+// exact matches like =0 or =1, because an exact match is not a CLDR category: in english =0 is not one, and in
+// latvian the category zero also contains 11.
+type ARBImporter struct {
+}
+
+// Import tries to parse the src bytes and imports that into the given resources.
+func (a ARBImporter) Import(dst *Resources, src io.Reader) error {
+	file, err := arb.Read(src)
+	if err != nil {
+		return fmt.Errorf("failed to import arb resources: %w", err)
+	}
+
+	dst.lock()
+	defer dst.unlock()
+
+	for _, msg := range file.Messages {
+		val, err := a.value(dst, msg)
+		if err != nil {
+			return fmt.Errorf("failed to import arb resources: %w", err)
+		}
+
+		dst.values[msg.Key] = val
+	}
+
+	return nil
+}
+
+// value converts the given message into a simple, plural or select value
+func (a ARBImporter) value(dst *Resources, msg arb.Message) (Value, error) {
+	parsed, err := icu.Parse(msg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message '%s': %w", msg.Key, err)
+	}
+
+	if needsMessageFormat(parsed) {
+		return a.messageValue(dst, msg)
+	}
+
+	conv := newIcuConverter(msg, parsed)
+	locale := dst.tag.String()
+
+	for i, node := range parsed {
+		var name string
+
+		var cases []icu.Case
+
+		var ordinal bool
+
+		switch t := node.(type) {
+		case *icu.Plural:
+			name, cases, ordinal = t.Name, t.Cases, t.Ordinal
+		case *icu.Select:
+			return a.selectValue(dst, msg, conv, parsed, i, t), nil
+		default:
+			continue
+		}
+
+		val := pluralValue{
+			Id:      msg.Key,
+			tag:     dst.tag,
+			locale:  locale,
+			note:    msg.Description,
+			ordinal: ordinal,
+		}
+
+		prefix, suffix := conv.render(parsed[:i], ""), conv.render(parsed[i+1:], "")
+		for _, c := range cases {
+			switch c.Key {
+			case zero, one, two, few, many, other:
+				val.set(c.Key, prefix+conv.render(c.Message, name)+suffix)
+			}
+		}
+
+		return val, nil
+	}
+
+	return simpleValue{
+		Id:     msg.Key,
+		locale: locale,
+		String: conv.render(parsed, ""),
+		note:   msg.Description,
+	}, nil
+}
+
+// messageValue keeps the message as it is, so that the named arguments are formatted by the ICU MessageFormat
+func (a ARBImporter) messageValue(dst *Resources, msg arb.Message) (Value, error) {
+	val, err := NewMessageText(dst.tag.String(), msg.Key, msg.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	res := val.(messageFormatValue)
+	res.note = msg.Description
+
+	return res, nil
+}
+
+// needsMessageFormat checks if the message cannot be expressed by a single plural or select value, because it
+// contains more than one plural or select, a nested one, an offset or an exact match.
+func needsMessageFormat(parsed icu.Message) bool {
+	var count int
+
+	for _, node := range parsed {
+		var cases []icu.Case
+
+		switch t := node.(type) {
+		case *icu.Plural:
+			if t.Offset != 0 || hasExactMatch(t.Cases) {
+				return true
+			}
+
+			cases = t.Cases
+		case *icu.Select:
+			cases = t.Cases
+		default:
+			continue
+		}
+
+		count++
+		if count > 1 {
+			return true
+		}
+
+		for _, c := range cases {
+			for _, nested := range c.Message {
+				switch nested.(type) {
+				case *icu.Plural, *icu.Select:
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// hasExactMatch checks if any case is an exact match like =0 instead of a CLDR category
+func hasExactMatch(cases []icu.Case) bool {
+	for _, c := range cases {
+		if strings.HasPrefix(c.Key, "=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectValue converts the message into a select, whose cases consist of the text in front of the select at index i,
+// the case and the text behind.
+func (a ARBImporter) selectValue(dst *Resources, msg arb.Message, conv icuConverter, parsed icu.Message, i int,
+	sel *icu.Select) Value {
+	val := NewSelectText(dst.tag.String(), msg.Key).(selectValue)
+	val.note = msg.Description
+
+	prefix, suffix := conv.render(parsed[:i], ""), conv.render(parsed[i+1:], "")
+	for _, c := range sel.Cases {
+		val = val.Case(c.Key, prefix+conv.render(c.Message, sel.Name)+suffix).(selectValue)
+	}
+
+	return val
+}
+
+// icuConverter renders icu messages as go format strings with positional arguments
+type icuConverter struct {
+	indices map[string]int
+	verbs   map[string]byte
+}
+
+func newIcuConverter(msg arb.Message, parsed icu.Message) icuConverter {
+	conv := icuConverter{indices: make(map[string]int), verbs: make(map[string]byte)}
+	used := make(map[string]string)
+
+	var order []string
+
+	for _, arg := range parsed.Arguments() {
+		used[arg.Name] = arg.Type
+	}
+
+	for _, ph := range msg.Placeholders {
+		if _, ok := used[ph.Name]; ok {
+			order = append(order, ph.Name)
+			conv.verbs[ph.Name] = arbVerb(ph.Type)
+		}
+	}
+
+	for _, arg := range parsed.Arguments() {
+		if _, ok := conv.verbs[arg.Name]; ok {
+			continue
+		}
+
+		order = append(order, arg.Name)
+
+		switch arg.Type {
+		case icu.TypePlural, icu.TypeSelectOrdinal:
+			conv.verbs[arg.Name] = 'd'
+		default:
+			conv.verbs[arg.Name] = 's'
+		}
+	}
+
+	for i, name := range order {
+		conv.indices[name] = i + 1
+	}
+
+	return conv
+}
+
+// arbVerb returns the go verb for the given placeholder type
+func arbVerb(typ string) byte {
+	switch typ {
+	case arb.TypeInt:
+		return 'd'
+	case arb.TypeDouble, arb.TypeNum:
+		return 'f'
+	default:
+		return 's'
+	}
+}
+
+// render converts the nodes into a format string. A # refers to the given plural argument. Plurals and selects
+// must have been resolved before, see needsMessageFormat.
+func (c icuConverter) render(msg icu.Message, pluralArg string) string {
+	sb := &strings.Builder{}
+
+	for _, node := range msg {
+		switch t := node.(type) {
+		case *icu.Text:
+			sb.WriteString(strings.ReplaceAll(t.Value, "%", "%%"))
+		case *icu.Argument:
+			sb.WriteString(c.directive(t.Name))
+		case *icu.Pound:
+			sb.WriteString(c.directive(pluralArg))
+		}
+	}
+
+	return sb.String()
+}
+
+func (c icuConverter) directive(name string) string {
+	return "%[" + strconv.Itoa(c.indices[name]) + "]" + string(c.verbs[name])
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestARBImporter(t *testing.T) {
+	setup()
+	importTestFile(t, ARBImporter{}, "de", "arb/app_test.arb")
+
+	res := From("de")
+
+	tests := []struct {
+		key      string
+		quantity int
+		args     []interface{}
+		want     string
+	}{
+		{"app_name", 0, nil, "LeichteApp"},
+		{"x_runs_around_y", 0, []interface{}{"Baum", "Nick", 3}, "Nick läuft 3 mal um den Baum"},
+		{"x_has_y_cats", 0, []interface{}{0, "Nick"}, "Nick hat keine Katze"},
+		{"x_has_y_cats", 1, []interface{}{1, "Nick"}, "Nick hat eine Katze"},
+		{"x_has_y_cats", 5, []interface{}{5, "Nick"}, "Nick hat 5 Katzen"},
+		{"x_is_invited", 1, nil, "Es ist zu 100% eingeladen"},
+		{"quoted", 0, nil, "Das {ist} keine 'Variable'"},
+	}
+
+	for _, tt := range tests {
+		str, err := res.QuantityText(tt.key, tt.quantity, tt.args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if str != tt.want {
+			t.Fatalf("%s: expected '%s' but got '%s'", tt.key, tt.want, str)
+		}
+	}
+
+	if note := res.Value("x_runs_around_y").Note(); note != "A person runs around something" {
+		t.Fatalf("unexpected note '%s'", note)
+	}
+
+	if _, ok := res.Value("x_has_y_cats").(messageFormatValue); !ok {
+		t.Fatalf("expected the exact match =0 as message format but got %+v", res.Value("x_has_y_cats"))
+	}
+}
+
+func TestARBImporterExactMatch(t *testing.T) {
+	tests := []struct {
+		locale   string
+		src      string
+		quantity int
+		want     string
+	}{
+		{"en", `{"items": "{count, plural, =0{no items} one{# item} other{# items}}"}`, 0, "no items"},
+		{"en", `{"items": "{count, plural, =0{no items} one{# item} other{# items}}"}`, 1, "1 item"},
+		{"en", `{"items": "{count, plural, =0{no items} one{# item} other{# items}}"}`, 11, "11 items"},
+		{"lv", `{"items": "{count, plural, =0{nav} zero{# vienību} one{# vienība} other{# vienības}}"}`, 0, "nav"},
+		{"lv", `{"items": "{count, plural, =0{nav} zero{# vienību} one{# vienība} other{# vienības}}"}`, 11,
+			"11 vienību"},
+		{"lv", `{"items": "{count, plural, =0{nav} zero{# vienību} one{# vienība} other{# vienības}}"}`, 21,
+			"21 vienība"},
+		{"lv", `{"items": "{count, plural, =1{viena} other{# vienības}}"}`, 21, "21 vienības"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+" "+tt.want, func(t *testing.T) {
+			setup()
+
+			if err := Import(ARBImporter{}, tt.locale, strings.NewReader(tt.src)); err != nil {
+				t.Fatal(err)
+			}
+
+			str, err := From(tt.locale).QuantityText("items", tt.quantity)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+}
+
+func TestARBImporterInvalid(t *testing.T) {
+	setup()
+
+	tests := []string{
+		`{"a": "{b"}`,
+	}
+
+	for _, tt := range tests {
+		if err := Import(ARBImporter{}, "de", strings.NewReader(tt)); err == nil {
+			t.Fatalf("expected error for '%s'", tt)
+		}
+	}
+}
+
+func TestARBImporterMessageFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		args Args
+		want string
+	}{
+		{"offset", `{"x": "{n, plural, offset:1 =0{niemand} =1{{host}} one{{host} und # Gast} other{{host} und # Gäste}}"}`,
+			Args{"n": 3, "host": "Nick"}, "Nick und 2 Gäste"},
+		{"nested", `{"x": "{g, select, female{{n, plural, one{ihre Katze} other{ihre # Katzen}}} other{{n, plural, ` +
+			`one{seine Katze} other{seine # Katzen}}}}"}`, Args{"g": "female", "n": 2}, "ihre 2 Katzen"},
+		{"nested other", `{"x": "{g, select, female{{n, plural, one{ihre Katze} other{ihre # Katzen}}} other{{n, ` +
+			`plural, one{seine Katze} other{seine # Katzen}}}}"}`, Args{"g": "male", "n": 1}, "seine Katze"},
+		{"two", `{"x": "{n, plural, one{# Katze} other{# Katzen}} und {m, plural, one{# Hund} other{# Hunde}}"}`,
+			Args{"n": 1, "m": 2}, "1 Katze und 2 Hunde"},
+		{"select and plural", `{"x": "{g, select, female{Sie} other{Er}} hat {n, plural, one{# Katze} ` +
+			`other{# Katzen}}"}`, Args{"g": "female", "n": 1}, "Sie hat 1 Katze"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+
+			if err := Import(ARBImporter{}, "de", strings.NewReader(tt.src)); err != nil {
+				t.Fatal(err)
+			}
+
+			val := From("de").Value("x")
+			if _, ok := val.(messageFormatValue); !ok {
+				t.Fatalf("expected a message format but got %+v", val)
+			}
+
+			str, err := val.Text(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/android"
+	"github.com/golangee/i18n/gettext"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"io"
+	"strings"
+)
+
+// maxPluralSample is the largest natural number which is evaluated to map gettext plural indices onto CLDR categories
+const maxPluralSample = 1000
+
+// A POImporter supports gettext PO and POT files. The msgctxt is used as key, if present, otherwise the msgid.
+// Extracted comments become the note of a value.
+// A msgid_plural becomes a plural, whose msgstr[n] indices are mapped onto CLDR categories by evaluating the
+// Plural-Forms header. Keys like "name[0]", "name[1]" are collected into a text array. Like gettext at runtime,
+// an untranslated or fuzzy msgstr falls back to its msgid. Positional directives like %1$s are converted into
+// %[1]s and a no-c-format entry is escaped. Directives, which go cannot format, like %,d, fail the import.
+type POImporter struct {
+	// Fuzzy imports translations flagged as fuzzy instead of falling back to the msgid.
+	Fuzzy bool
+}
+
+// Import tries to parse the src bytes and imports that into the given resources.
+func (a POImporter) Import(dst *Resources, src io.Reader) error {
+	catalog, err := gettext.Read(src)
+	if err != nil {
+		return fmt.Errorf("failed to import po resources: %w", err)
+	}
+
+	// the placeholder of a POT is treated like a missing header
+	forms := gettext.DefaultPluralForms(dst.tag.String())
+	header := catalog.Get(gettext.HeaderPluralForms)
+
+	if header != "" && strings.Join(strings.Fields(header), " ") != gettext.TemplatePluralForms {
+		forms, err = gettext.ParsePluralForms(header)
+		if err != nil {
+			return fmt.Errorf("failed to import po resources: %w", err)
+		}
+	}
+
+	if err := a.importCatalog(dst, catalog, poCategories(forms, dst.tag)); err != nil {
+		return fmt.Errorf("failed to import po resources: %w", err)
+	}
+
+	return nil
+}
+
+// importCatalog copies and converts the given gettext entries into our i18n resources. Nothing is imported, if an
+// entry cannot be converted.
+func (a POImporter) importCatalog(dst *Resources, catalog gettext.Catalog, categories []string) error {
+	locale := dst.tag.String()
+	values := make(map[string]Value)
+	arrays := arrayItems{}
+
+	for _, entry := range catalog.Entries {
+		key := entry.Key()
+
+		if !entry.IsPlural() {
+			text, err := a.text(entry, entry.MsgStr, entry.MsgID)
+			if err != nil {
+				return fmt.Errorf("invalid entry '%s': %w", key, err)
+			}
+
+			if name, idx, ok := splitArrayKey(key); ok {
+				arrays.add(name, idx, text)
+				continue
+			}
+
+			values[key] = simpleValue{
+				Id:     key,
+				locale: locale,
+				String: text,
+				note:   strings.Join(entry.ExtractedComments, "\n"),
+			}
+
+			continue
+		}
+
+		val := pluralValue{
+			Id:     key,
+			tag:    dst.tag,
+			locale: locale,
+			note:   strings.Join(entry.ExtractedComments, "\n"),
+		}
+
+		last := ""
+		for i, category := range categories {
+			str, fallback := "", entry.MsgIDPlural
+			if i < len(entry.MsgStrPlural) {
+				str = entry.MsgStrPlural[i]
+			}
+
+			if i == 0 {
+				fallback = entry.MsgID
+			}
+
+			text, err := a.text(entry, str, fallback)
+			if err != nil {
+				return fmt.Errorf("invalid entry '%s': %w", key, err)
+			}
+
+			last = text
+			if val.get(category) == "" {
+				val.set(category, last)
+			}
+		}
+
+		// gettext has no dedicated other form, e.g. for decimals in slavic languages, so the last one is used
+		if val.other == "" {
+			val.other = last
+		}
+
+		values[key] = val
+	}
+
+	dst.lock()
+	defer dst.unlock()
+
+	for key, value := range values {
+		dst.values[key] = value
+	}
+
+	arrays.importInto(dst)
+
+	return nil
+}
+
+// text returns the converted msgstr or the fallback, if it is untranslated or fuzzy
+func (a POImporter) text(entry gettext.Entry, msgstr, fallback string) (string, error) {
+	if msgstr == "" || (!a.Fuzzy && entry.HasFlag(gettext.FlagFuzzy)) {
+		msgstr = fallback
+	}
+
+	if entry.HasFlag(gettext.FlagNoCFormat) {
+		return strings.ReplaceAll(msgstr, "%", "%%"), nil
+	}
+
+	return android.AsGoFormat(msgstr)
+}
+
+// poCategories maps each msgstr index onto the CLDR category of the first natural number, which selects that index.
+// The undefined locale has only an other category in CLDR, but a msgid and msgid_plural are english by convention.
+func poCategories(forms gettext.PluralForms, tag language.Tag) []string {
+	if tag == language.Und {
+		tag = language.English
+	}
+
+	res := make([]string, forms.NPlurals)
+	found := 0
+
+	for n := 0; n <= maxPluralSample && found < len(res); n++ {
+		i := forms.Index(n)
+		if res[i] == "" {
+			res[i] = formName(plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0))
+			found++
+		}
+	}
+
+	for i := range res {
+		if res[i] == "" {
+			res[i] = other
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,165 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"github.com/golangee/i18n/gettext"
+	"golang.org/x/text/language"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPOImporter(t *testing.T) {
+	setup()
+
+	file, err := os.Open("gettext/strings_test.po")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	err = Import(POImporter{}, "de", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := From("de")
+	tests := []struct {
+		id       string
+		quantity int
+		args     []interface{}
+		want     string
+	}{
+		{"app_name", 0, nil, "LeichteApp"},
+		{"hello_world", 0, nil, "Hallo Welt"},
+		{"x_runs_around_Y_and_sings_z", 0, []interface{}{"Nick", "Baum", "laut"}, "Nick läuft um den Baum und singt laut"},
+		{"x_has_y_cats", 1, []interface{}{"Nick", 1}, "Nick hat 1 Katze"},
+		{"x_has_y_cats", 3, []interface{}{"Nick", 3}, "der Besitzer von 3 Katzen ist Nick"},
+		{"fuzzy", 0, nil, "source text"},
+		{"percent", 0, nil, "100% fertig"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			str, err := res.QuantityText(tt.id, tt.quantity, tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Errorf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+
+	arr, err := res.TextArray("selector_details_array")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"erste Zeile", "zweite Zeile"}; !reflect.DeepEqual(arr, expected) {
+		t.Fatalf("expected %v but got %v", expected, arr)
+	}
+}
+
+func TestPOCategories(t *testing.T) {
+	tests := []struct {
+		lang string
+		want []string
+	}{
+		{"de", []string{one, other}},
+		{"fr", []string{one, other}},
+		{"ja", []string{other}},
+		{"ru", []string{one, few, many}},
+		{"ar", []string{zero, one, two, few, many, other}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			got := poCategories(gettext.DefaultPluralForms(tt.lang), language.Make(tt.lang))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("poCategories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPOExporter(t *testing.T) {
+	setup()
+
+	file, err := os.Open("example/strings_test.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	err = Import(AndroidImporter{}, "und", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = Export(POExporter{Template: true}, "und", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pot := buf.String()
+	for _, expected := range []string{
+		"msgctxt \"x_has_y_cats\"\nmsgid \"%1$s has %2$d cat\"\nmsgid_plural \"the owner of %2$d cats is %1$s\"\n" +
+			"msgstr[0] \"\"\nmsgstr[1] \"\"\n",
+		"msgctxt \"selector_details_array[3]\"\nmsgid \"fourth line\"\nmsgstr \"\"\n",
+		"#, c-format\nmsgctxt \"hello_x\"\nmsgid \"Hello %s\"\n",
+	} {
+		if !strings.Contains(pot, expected) {
+			t.Fatalf("expected\n%s\nin\n%s", expected, pot)
+		}
+	}
+
+	// a template imports like an untranslated catalog, which falls back to the msgid
+	res := newResources(language.Und)
+	if err := (POImporter{}).Import(res, strings.NewReader(pot)); err != nil {
+		t.Fatal(err)
+	}
+
+	org := From("und")
+	for _, key := range org.Keys() {
+		if !equalValues(res.Value(key), org.Value(key)) {
+			t.Fatalf("expected %+v but got %+v", org.Value(key), res.Value(key))
+		}
+	}
+
+	// a monolingual round trip must not change anything
+	buf.Reset()
+	err = Export(POExporter{}, "und", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res = newResources(language.Und)
+	err = POImporter{}.Import(res, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range org.Keys() {
+		if !equalValues(res.Value(key), org.Value(key)) {
+			t.Fatalf("expected %+v but got %+v", org.Value(key), res.Value(key))
+		}
+	}
+}
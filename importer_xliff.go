@@ -0,0 +1,158 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/xliff"
+	"io"
+	"strings"
+)
+
+// An XLIFFImporter supports XLIFF 1.2 and 2.0 documents, as exchanged by translation tools like SDL Trados or memoQ.
+// Each trans-unit (1.2) or unit (2.0) becomes a simple text. A group with a plural restype or type, e.g.
+// restype="x-gettext-plurals" or type="x:plurals", becomes a plural, whose units are identified by their CLDR category
+// as resname, name or id suffix. A group with an array restype or type, e.g. restype="x-android-string-array",
+// becomes a text array in document order. The target is used if available, otherwise the source.
+type XLIFFImporter struct {
+}
+
+// Import tries to parse the src bytes and imports that into the given resources.
+func (a XLIFFImporter) Import(dst *Resources, src io.Reader) error {
+	doc, err := xliff.Read(src)
+	if err != nil {
+		return fmt.Errorf("failed to import xliff resources: %w", err)
+	}
+
+	dst.lock()
+	defer dst.unlock()
+
+	for _, file := range doc.Files {
+		if err := importXLIFFGroup(dst, file.Group); err != nil {
+			return fmt.Errorf("failed to import xliff file '%s': %w", file.Original, err)
+		}
+
+		if err := importXLIFFGroup(dst, file.Body); err != nil {
+			return fmt.Errorf("failed to import xliff file '%s': %w", file.Original, err)
+		}
+	}
+
+	return nil
+}
+
+// importXLIFFGroup copies and converts the given xliff group recursively into our i18n resources.
+func importXLIFFGroup(dst *Resources, group xliff.Group) error {
+	locale := dst.tag.String()
+
+	for _, seg := range group.Segments() {
+		text, err := seg.Text()
+		if err != nil {
+			return fmt.Errorf("invalid unit '%s': %w", seg.ID, err)
+		}
+
+		dst.values[seg.ID] = simpleValue{
+			Id:     seg.ID,
+			locale: locale,
+			String: text,
+		}
+	}
+
+	for _, child := range group.Groups {
+		var err error
+
+		switch {
+		case child.IsPlural():
+			err = importXLIFFPlural(dst, child)
+		case child.IsArray():
+			err = importXLIFFArray(dst, child)
+		default:
+			err = importXLIFFGroup(dst, child)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func importXLIFFPlural(dst *Resources, group xliff.Group) error {
+	val := pluralValue{
+		Id:     group.Key(),
+		tag:    dst.tag,
+		locale: dst.tag.String(),
+	}
+
+	for _, seg := range group.Segments() {
+		text, err := seg.Text()
+		if err != nil {
+			return fmt.Errorf("invalid plural '%s': %w", val.Id, err)
+		}
+
+		switch xliffPluralCategory(seg.ID) {
+		case zero:
+			val.zero = text
+		case one:
+			val.one = text
+		case two:
+			val.two = text
+		case few:
+			val.few = text
+		case many:
+			val.many = text
+		default:
+			val.other = text
+		}
+	}
+
+	dst.values[val.Id] = val
+
+	return nil
+}
+
+func importXLIFFArray(dst *Resources, group xliff.Group) error {
+	segments := group.Segments()
+	tmp := make([]string, 0, len(segments))
+
+	for _, seg := range segments {
+		text, err := seg.Text()
+		if err != nil {
+			return fmt.Errorf("invalid array '%s': %w", group.Key(), err)
+		}
+
+		tmp = append(tmp, text)
+	}
+
+	dst.values[group.Key()] = arrayValue{
+		Id:      group.Key(),
+		locale:  dst.tag.String(),
+		Strings: tmp,
+	}
+
+	return nil
+}
+
+// xliffPluralCategory returns the CLDR category from a unit key, which is either the category itself or suffixed
+// with it, like "x_has_y_cats:one", "x_has_y_cats[one]" or "x_has_y_cats.one".
+func xliffPluralCategory(key string) string {
+	key = strings.ToLower(strings.TrimSuffix(key, "]"))
+	if i := strings.LastIndexAny(key, ":[.#/_-"); i >= 0 {
+		key = key[i+1:]
+	}
+
+	return key
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestXLIFFImporter(t *testing.T) {
+	setup()
+
+	file, err := os.Open("xliff/strings_test.xlf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	err = Import(XLIFFImporter{}, "de-DE", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := From("de-DE")
+
+	str, err := res.Text("x_runs_around_Y_and_sings_z", "Nick", "Baum", "laut")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "Nick läuft um den Baum und singt laut"; str != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, str)
+	}
+
+	str, err = res.QuantityText("x_has_y_cats", 2, "Nick", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "der Besitzer von 2 Katzen ist Nick"; str != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, str)
+	}
+
+	arr, err := res.TextArray("selector_details_array")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"erste Zeile", "second line"}; !reflect.DeepEqual(arr, expected) {
+		t.Fatalf("expected %v but got %v", expected, arr)
+	}
+
+	str, err = res.Text("percent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "100% done"; str != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, str)
+	}
+}
+
+func TestXLIFF2Importer(t *testing.T) {
+	setup()
+
+	file, err := os.Open("xliff/strings_test.xliff")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer file.Close()
+
+	err = Import(XLIFFImporter{}, "en", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := From("en")
+
+	str, err := res.QuantityText("x_has_y_cats", 1, "Nick", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "Nick has 1 cat"; str != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, str)
+	}
+
+	arr, err := res.TextArray("selector_details_array")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"first line", "second line"}; !reflect.DeepEqual(arr, expected) {
+		t.Fatalf("expected %v but got %v", expected, arr)
+	}
+}
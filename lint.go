@@ -0,0 +1,209 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"go/types"
+)
+
+// ErrUnknownKey indicates that a Resources method is called with a key, which no strings*.xml file contains
+type ErrUnknownKey struct {
+	Key string
+}
+
+func (e ErrUnknownKey) Error() string {
+	return "unknown key '" + e.Key + "'"
+}
+
+// ErrArgumentCount indicates that a Resources method is called with more or less arguments than the text expects
+type ErrArgumentCount struct {
+	Value    Value
+	Found    int
+	Expected int
+}
+
+func (e ErrArgumentCount) Error() string {
+	return located(fmt.Sprintf("%s expects %d arguments but got %d", e.Value.ID(), e.Expected, e.Found), nil, e.Value)
+}
+
+// ErrArgumentType indicates that the argument at the index Arg cannot be formatted by the directive of the text
+type ErrArgumentType struct {
+	Value Value
+	Arg   int
+	Spec  PrintfFormatSpecifier
+	Type  types.Type
+}
+
+func (e ErrArgumentType) Error() string {
+	return located(fmt.Sprintf("%s formats the argument %d with %s but it is a %s", e.Value.ID(), e.Arg+1,
+		e.Spec.String(), e.Type.String()), nil, e.Value)
+}
+
+// CheckCall validates the call of the Resources method, like Text, QuantityText or TextArray, with the key and
+// the static types of the formatted arguments. The quantity is not part of args. A nil type is unknown and matches
+// any directive. The errors are ErrUnknownKey, ErrArgumentCount or ErrArgumentType.
+func (t *Translations) CheckCall(method string, key string, args []types.Type) []error {
+	value := t.values[key]
+	if value == nil {
+		return []error{ErrUnknownKey{Key: key}}
+	}
+
+	var text string
+
+	switch v := value.(type) {
+	case simpleValue:
+		text = v.String
+	case pluralValue:
+		text = v.other
+	case selectValue:
+		text = v.other
+	case arrayValue:
+		if len(v.Strings) > 0 {
+			text = v.Strings[0]
+		}
+	case messageFormatValue:
+		return checkMessageFormatCall(v, method, args)
+	}
+
+	if method == "TextArray" {
+		return nil
+	}
+
+	return checkPrintfCall(value, text, args)
+}
+
+// checkMessageFormatCall only checks the positional arguments of Text, because QuantityText inserts the quantity
+// for the first plural argument
+func checkMessageFormatCall(value messageFormatValue, method string, args []types.Type) []error {
+	if method != "Text" {
+		return nil
+	}
+
+	if len(args) == 1 {
+		if _, ok := underlying(args[0]).(*types.Map); ok {
+			return nil
+		}
+	}
+
+	if expected := len(value.arguments()); len(args) != expected {
+		return []error{ErrArgumentCount{Value: value, Found: len(args), Expected: expected}}
+	}
+
+	return nil
+}
+
+// checkPrintfCall applies the rules of the fmt package. Extra arguments are only allowed, if the text contains
+// explicit argument indices.
+func checkPrintfCall(value Value, text string, args []types.Type) []error {
+	specs := ParsePrintf(text)
+	expected, reordered := 0, false
+
+	for _, spec := range specs {
+		if spec.BadIndex {
+			continue
+		}
+
+		for _, arg := range []int{spec.Arg, spec.WidthArg, spec.PrecisionArg} {
+			if arg+1 > expected {
+				expected = arg + 1
+			}
+		}
+
+		reordered = reordered || spec.Reordered
+	}
+
+	if len(args) < expected || len(args) > expected && !reordered {
+		return []error{ErrArgumentCount{Value: value, Found: len(args), Expected: expected}}
+	}
+
+	var errs []error
+
+	for _, spec := range specs {
+		if spec.BadIndex {
+			continue
+		}
+
+		for _, arg := range []int{spec.WidthArg, spec.PrecisionArg} {
+			if arg >= 0 && !matchesVerb('d', args[arg]) {
+				errs = append(errs, ErrArgumentType{Value: value, Arg: arg, Spec: spec, Type: args[arg]})
+			}
+		}
+
+		if spec.Arg >= 0 && !matchesVerb(spec.Verb(), args[spec.Arg]) {
+			errs = append(errs, ErrArgumentType{Value: value, Arg: spec.Arg, Spec: spec, Type: args[spec.Arg]})
+		}
+	}
+
+	return errs
+}
+
+// matchesVerb is a simplified version of the printf check of go vet. Interfaces and fmt.Formatter implementations,
+// like Decimal, Currency or DateTime, decide at runtime and always match.
+func matchesVerb(verb byte, typ types.Type) bool {
+	if typ == nil || hasMethod(typ, "Format") {
+		return true
+	}
+
+	if _, ok := underlying(typ).(*types.Interface); ok {
+		return true
+	}
+
+	basic, _ := underlying(typ).(*types.Basic)
+	is := func(info types.BasicInfo) bool {
+		return basic != nil && basic.Info()&info != 0
+	}
+
+	switch verb {
+	case 'd', 'c', 'U', 'o', 'O', 'b':
+		return is(types.IsInteger) || verb == 'b' && is(types.IsFloat|types.IsComplex)
+	case 'x', 'X':
+		return is(types.IsInteger|types.IsFloat|types.IsComplex|types.IsString) || isBytes(typ) ||
+			hasMethod(typ, "String") || hasMethod(typ, "Error")
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return is(types.IsFloat | types.IsComplex)
+	case 's', 'q':
+		return is(types.IsString) || verb == 'q' && is(types.IsInteger) || isBytes(typ) ||
+			hasMethod(typ, "String") || hasMethod(typ, "Error")
+	case 't':
+		return is(types.IsBoolean)
+	default:
+		return true
+	}
+}
+
+func underlying(typ types.Type) types.Type {
+	if typ == nil {
+		return nil
+	}
+
+	return typ.Underlying()
+}
+
+func hasMethod(typ types.Type, name string) bool {
+	return types.NewMethodSet(typ).Lookup(nil, name) != nil
+}
+
+func isBytes(typ types.Type) bool {
+	slice, ok := underlying(typ).(*types.Slice)
+	if !ok {
+		return false
+	}
+
+	elem, ok := slice.Elem().Underlying().(*types.Basic)
+
+	return ok && elem.Kind() == types.Byte
+}
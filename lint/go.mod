@@ -0,0 +1,20 @@
+module github.com/golangee/i18n/lint
+
+go 1.26.0
+
+require (
+	github.com/golangee/i18n v0.0.0
+	golang.org/x/tools v0.50.0
+)
+
+require (
+	github.com/dave/jennifer v1.4.0 // indirect
+	github.com/golangee/log v0.0.0-20201214095632-610ba2dec6e5 // indirect
+	github.com/iancoleman/strcase v0.0.0-20191112232945-16388991a334 // indirect
+	golang.org/x/mod v0.41.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+	golang.org/x/text v0.3.2 // indirect
+)
+
+// the analyzer is developed along with the i18n package
+replace github.com/golangee/i18n => ../
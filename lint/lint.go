@@ -0,0 +1,200 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint provides the go/analysis Analyzer, which checks calls with literal keys, like
+// res.Text("hello_x", name), against the strings*.xml files of the module. Use it with go vet, like
+//
+//	go vet -vettool=$(which i18nlint) ./...
+package lint
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Analyzer reports unknown keys, wrong argument counts and arguments, which do not match the printf verbs, in calls
+// of Resources.Text, Resources.QuantityText and Resources.TextArray.
+// nolint: gochecknoglobals
+var Analyzer = &analysis.Analyzer{
+	Name:     "i18n",
+	Doc:      "check the literal keys and arguments of i18n.Resources calls against the strings*.xml files",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// root is the directory, which contains the strings*.xml files, instead of the module root
+// nolint: gochecknoglobals
+var root string
+
+// translations caches the scanned translations by their directory, because all packages of a module share them
+// nolint: gochecknoglobals
+var translations sync.Map
+
+// scanResult is the cached result of i18n.ScanTranslations
+type scanResult struct {
+	once sync.Once
+	t    *i18n.Translations
+	err  error
+}
+
+// methods are the checked Resources methods and the index of their first formatted argument
+// nolint: gochecknoglobals
+var methods = map[string]int{
+	"Text":         1,
+	"QuantityText": 2,
+	"TextArray":    1,
+}
+
+func init() {
+	Analyzer.Flags.StringVar(&root, "root", "", "the directory of the strings*.xml files, the module root by default")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if len(pass.Files) == 0 {
+		return nil, nil
+	}
+
+	dir := root
+	if dir == "" {
+		dir = moduleRoot(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()))
+	}
+
+	t, err := load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// without any strings files, the keys are imported from somewhere else
+	if len(t.Keys()) == 0 {
+		return nil, nil
+	}
+
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+
+		method, first := resourcesMethod(pass, call)
+		if method == "" || len(call.Args) == 0 {
+			return
+		}
+
+		key := pass.TypesInfo.Types[call.Args[0]].Value
+		if key == nil || key.Kind() != constant.String {
+			return
+		}
+
+		// the types of a spread slice are unknown
+		if call.Ellipsis.IsValid() {
+			if t.Value(constant.StringVal(key)) == nil {
+				pass.Reportf(call.Args[0].Pos(), "%s", i18n.ErrUnknownKey{Key: constant.StringVal(key)})
+			}
+
+			return
+		}
+
+		var args []types.Type
+		if first < len(call.Args) {
+			for _, arg := range call.Args[first:] {
+				args = append(args, pass.TypesInfo.TypeOf(arg))
+			}
+		}
+
+		for _, err := range t.CheckCall(method, constant.StringVal(key), args) {
+			switch e := err.(type) {
+			case i18n.ErrUnknownKey:
+				pass.Reportf(call.Args[0].Pos(), "%s", e)
+			case i18n.ErrArgumentType:
+				pass.Reportf(call.Args[first+e.Arg].Pos(), "%s", e)
+			default:
+				pass.Reportf(call.Pos(), "%s", e)
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// resourcesMethod returns the name of the called Resources method and the index of its first formatted argument
+// or the empty string
+func resourcesMethod(pass *analysis.Pass, call *ast.CallExpr) (string, int) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return "", 0
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return "", 0
+	}
+
+	typ := recv.Type()
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Name() != "Resources" || named.Obj().Pkg() == nil ||
+		named.Obj().Pkg().Path() != "github.com/golangee/i18n" {
+		return "", 0
+	}
+
+	first, ok := methods[fn.Name()]
+	if !ok {
+		return "", 0
+	}
+
+	return fn.Name(), first
+}
+
+// load scans the directory only once
+func load(dir string) (*i18n.Translations, error) {
+	v, _ := translations.LoadOrStore(dir, &scanResult{})
+	res := v.(*scanResult)
+
+	res.once.Do(func() {
+		res.t, res.err = i18n.ScanTranslations(dir)
+		if res.err != nil {
+			res.err = fmt.Errorf("unable to scan translations in %s: %w", dir, res.err)
+		}
+	})
+
+	return res.t, res.err
+}
+
+// moduleRoot returns the next parent directory with a go.mod file or dir itself
+func moduleRoot(dir string) string {
+	for parent := dir; ; {
+		if stat, err := os.Stat(filepath.Join(parent, "go.mod")); err == nil && stat.Mode().IsRegular() {
+			return parent
+		}
+
+		next := filepath.Dir(parent)
+		if next == parent {
+			return dir
+		}
+
+		parent = next
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"golang.org/x/tools/go/analysis/analysistest"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	if err := Analyzer.Flags.Set("root", filepath.Join(testdata, "src", "a")); err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.Run(t, testdata, Analyzer, "a")
+}
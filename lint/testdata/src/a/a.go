@@ -0,0 +1,22 @@
+package a
+
+import "github.com/golangee/i18n"
+
+const helloKey = "hello_x"
+
+func texts(res *i18n.Resources, key string, args []interface{}) {
+	_, _ = res.Text("hello_x", "Nick")
+	_, _ = res.Text(helloKey, "Nick")
+	_, _ = res.Text("hello_y", "Nick") // want `unknown key 'hello_y'`
+	_, _ = res.Text("hello_x")         // want `hello_x expects 1 arguments but got 0`
+	_, _ = res.Text("hello_x", 42)     // want `hello_x formats the argument 1 with %s but it is a int`
+	_, _ = res.Text("hello_x", args...)
+	_, _ = res.Text("hello_y", args...) // want `unknown key 'hello_y'`
+	_, _ = res.Text(key, 42)
+
+	_, _ = res.QuantityText("x_has_y_cats", 2, "Nick", 2)
+	_, _ = res.QuantityText("x_has_y_cats", 2, "Nick", "2") // want `x_has_y_cats formats the argument 2 with %\[2\]d but it is a string`
+
+	_, _ = res.TextArray("days")
+	_, _ = res.TextArray("weeks") // want `unknown key 'weeks'`
+}
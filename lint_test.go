@@ -0,0 +1,100 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+func TestScanTranslations(t *testing.T) {
+	translations, err := ScanTranslations("./example")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if translations.Value("hello_x") == nil || translations.Value("missing") != nil {
+		t.Fatalf("unexpected keys %v", translations.Keys())
+	}
+}
+
+func TestCheckCall(t *testing.T) {
+	integer, str, float := types.Typ[types.Int], types.Typ[types.String], types.Typ[types.Float64]
+	empty := types.NewInterfaceType(nil, nil)
+	args := types.NewMap(str, empty)
+
+	// a named type with a Format method, like Currency
+	formatter := types.NewNamed(types.NewTypeName(0, nil, "Currency", nil), types.Typ[types.Float64], nil)
+	formatter.AddMethod(types.NewFunc(0, nil, "Format", types.NewSignature(nil, nil, nil, false)))
+
+	translations := &Translations{values: map[string]Value{}}
+	for _, v := range []Value{
+		NewText("en", "hello_x", "Hello %s"),
+		NewText("en", "x_runs", "%[2]s runs %[1]d km"),
+		NewText("en", "width", "%*d"),
+		NewQuantityText("en", "x_cats").One("%s has %d cat").Other("%s has %d cats"),
+		NewTextArray("en", "days", "Monday", "Tuesday"),
+		MustMessageText("en", "x_has_cats", "{name} has {count, plural, one {# cat} other {# cats}}"),
+	} {
+		translations.values[v.ID()] = v
+	}
+
+	tests := []struct {
+		method string
+		key    string
+		args   []types.Type
+		want   []string
+	}{
+		{"Text", "hello_x", []types.Type{str}, nil},
+		{"Text", "hello_y", []types.Type{str}, []string{"unknown key 'hello_y'"}},
+		{"Text", "hello_x", nil, []string{"hello_x expects 1 arguments but got 0"}},
+		{"Text", "hello_x", []types.Type{str, str}, []string{"hello_x expects 1 arguments but got 2"}},
+		{"Text", "hello_x", []types.Type{integer}, []string{"hello_x formats the argument 1 with %s but it is a int"}},
+		{"Text", "hello_x", []types.Type{empty}, nil},
+		{"Text", "hello_x", []types.Type{formatter}, nil},
+		{"Text", "hello_x", []types.Type{nil}, nil},
+		{"Text", "x_runs", []types.Type{integer, str}, nil},
+		{"Text", "x_runs", []types.Type{str, integer}, []string{
+			"x_runs formats the argument 1 with %[1]d but it is a string",
+			"x_runs formats the argument 2 with %[2]s but it is a int",
+		}},
+		{"Text", "width", []types.Type{float, integer}, []string{
+			"width formats the argument 1 with %*d but it is a float64",
+		}},
+		{"QuantityText", "x_cats", []types.Type{str, integer}, nil},
+		{"QuantityText", "x_cats", []types.Type{str}, []string{"x_cats expects 2 arguments but got 1"}},
+		{"TextArray", "days", nil, nil},
+		{"TextArray", "weeks", nil, []string{"unknown key 'weeks'"}},
+		{"Text", "x_has_cats", []types.Type{args}, nil},
+		{"Text", "x_has_cats", []types.Type{integer, str}, nil},
+		{"Text", "x_has_cats", []types.Type{integer}, []string{"x_has_cats expects 2 arguments but got 1"}},
+		{"QuantityText", "x_has_cats", []types.Type{str}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s %v", tt.method, tt.key, tt.args), func(t *testing.T) {
+			var got []string
+			for _, err := range translations.CheckCall(tt.method, tt.key, tt.args) {
+				got = append(got, err.Error())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %q but got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"golang.org/x/text/language"
+	"strings"
+)
+
+// ListStyle selects the CLDR list patterns, which join the items of a list
+type ListStyle int
+
+const (
+	// ConjunctionList joins all items, like "a, b, and c" or "a, b und c"
+	ConjunctionList ListStyle = iota
+	// DisjunctionList joins alternatives, like "a, b, or c" or "a, b oder c"
+	DisjunctionList
+	// UnitList joins the parts of a measurement, like "3 hours, 5 minutes"
+	UnitList
+)
+
+// String returns the name of the style, which is also the value of the tools:list attribute of an android
+// string-array.
+func (s ListStyle) String() string {
+	switch s {
+	case DisjunctionList:
+		return "disjunction"
+	case UnitList:
+		return "unit"
+	default:
+		return "conjunction"
+	}
+}
+
+// parseListStyle is the inverse of ListStyle.String
+func parseListStyle(name string) (ListStyle, error) {
+	for _, s := range []ListStyle{ConjunctionList, DisjunctionList, UnitList} {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+
+	return ConjunctionList, fmt.Errorf("unknown list style '%s'", name)
+}
+
+// listPatterns join two items. Lists with more items use start for the first two items, middle for the following
+// and end for the last.
+type listPatterns struct {
+	two, start, middle, end string
+}
+
+// simpleList returns the patterns of languages, which separate the last item like the two items
+func simpleList(separator, last string) listPatterns {
+	return listPatterns{
+		two:    "{0}" + last + "{1}",
+		start:  "{0}" + separator + "{1}",
+		middle: "{0}" + separator + "{1}",
+		end:    "{0}" + last + "{1}",
+	}
+}
+
+// rootLists are the patterns of the CLDR root locale, which are used for any unknown language
+// nolint: gochecknoglobals
+var rootLists = [3]listPatterns{simpleList(", ", ", "), simpleList(", ", ", "), simpleList(", ", ", ")}
+
+// lists contains the CLDR list patterns by the base language, indexed by the ListStyle
+// nolint: gochecknoglobals
+var lists = map[string][3]listPatterns{
+	"en": {
+		{two: "{0} and {1}", start: "{0}, {1}", middle: "{0}, {1}", end: "{0}, and {1}"},
+		{two: "{0} or {1}", start: "{0}, {1}", middle: "{0}, {1}", end: "{0}, or {1}"},
+		simpleList(", ", ", "),
+	},
+	"de": {simpleList(", ", " und "), simpleList(", ", " oder "), simpleList(", ", " und ")},
+	"fr": {simpleList(", ", " et "), simpleList(", ", " ou "), simpleList(", ", " et ")},
+	"es": {simpleList(", ", " y "), simpleList(", ", " o "), simpleList(", ", " y ")},
+	"it": {simpleList(", ", " e "), simpleList(", ", " o "), simpleList(", ", " e ")},
+	"pt": {simpleList(", ", " e "), simpleList(", ", " ou "), simpleList(", ", " e ")},
+	"nl": {simpleList(", ", " en "), simpleList(", ", " of "), simpleList(", ", " en ")},
+	"ru": {simpleList(", ", " и "), simpleList(", ", " или "), simpleList(" ", " ")},
+	"ja": {
+		simpleList("、", "、"),
+		{two: "{0}または{1}", start: "{0}、{1}", middle: "{0}、{1}", end: "{0}、または{1}"},
+		simpleList(" ", " "),
+	},
+	"zh": {simpleList("、", "和"), simpleList("、", "或"), simpleList("", "")},
+}
+
+// list joins the items by the CLDR list patterns of the language
+func (p *numberPrinter) list(items []string, style ListStyle) string {
+	// the base of an undetermined language is guessed as english, but it must use the root patterns
+	base, confidence := p.tag.Base()
+
+	patterns, ok := lists[base.String()]
+	if !ok || confidence < language.High {
+		patterns = rootLists
+	}
+
+	if style < ConjunctionList || style > UnitList {
+		style = ConjunctionList
+	}
+
+	pattern := patterns[style]
+
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return joinList(pattern.two, items[0], items[1])
+	}
+
+	// the patterns are applied from the end, like start(a, middle(b, end(c, d)))
+	res := joinList(pattern.end, items[len(items)-2], items[len(items)-1])
+	for i := len(items) - 3; i > 0; i-- {
+		res = joinList(pattern.middle, items[i], res)
+	}
+
+	return joinList(pattern.start, items[0], res)
+}
+
+// joinList replaces {0} and {1} of the pattern, without interpreting the items as patterns
+func joinList(pattern string, item0, item1 string) string {
+	i0, i1 := strings.Index(pattern, "{0}"), strings.Index(pattern, "{1}")
+
+	return pattern[:i0] + item0 + pattern[i0+3:i1] + item1 + pattern[i1+3:]
+}
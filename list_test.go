@@ -0,0 +1,104 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/text/language"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		locale string
+		items  []string
+		style  ListStyle
+		want   string
+	}{
+		{"en", nil, ConjunctionList, ""},
+		{"en", []string{"a"}, ConjunctionList, "a"},
+		{"en", []string{"a", "b"}, ConjunctionList, "a and b"},
+		{"en", []string{"a", "b", "c"}, ConjunctionList, "a, b, and c"},
+		{"en", []string{"a", "b", "c", "d"}, DisjunctionList, "a, b, c, or d"},
+		{"en", []string{"3 hours", "5 minutes"}, UnitList, "3 hours, 5 minutes"},
+		{"de", []string{"a", "b", "c", "d"}, ConjunctionList, "a, b, c und d"},
+		{"de", []string{"a", "b"}, DisjunctionList, "a oder b"},
+		{"ja", []string{"a", "b", "c"}, DisjunctionList, "a、b、またはc"},
+		{"zh", []string{"a", "b", "c"}, ConjunctionList, "a、b和c"},
+		{"xx", []string{"a", "b", "c"}, ConjunctionList, "a, b, c"},
+		{"en", []string{"{0}", "{1}", "{0}"}, ConjunctionList, "{0}, {1}, and {0}"},
+		{"en", []string{"a", "b"}, ListStyle(42), "a and b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v %v", tt.locale, tt.items, tt.style), func(t *testing.T) {
+			if got := newResources(language.Make(tt.locale)).List(tt.items, tt.style); got != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestListStyle(t *testing.T) {
+	for _, s := range []ListStyle{ConjunctionList, DisjunctionList, UnitList} {
+		if got, err := parseListStyle(s.String()); err != nil || got != s {
+			t.Fatalf("expected %v but got %v: %v", s, got, err)
+		}
+	}
+
+	if _, err := parseListStyle("and"); err == nil {
+		t.Fatal("expected an unknown list style")
+	}
+}
+
+func TestAndroidList(t *testing.T) {
+	setup()
+
+	src := `<resources xmlns:tools="http://schemas.android.com/tools">
+    <string-array name="toppings" tools:list="conjunction">
+        <item>Käse</item>
+        <item>Salami</item>
+        <item>Oliven</item>
+    </string-array>
+</resources>`
+
+	if err := Import(AndroidImporter{}, "de", strings.NewReader(src)); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := Export(AndroidExporter{}, "de", buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), `<resources xmlns:tools="http://schemas.android.com/tools">`) ||
+		!strings.Contains(buf.String(), `<string-array name="toppings" tools:list="conjunction">`) {
+		t.Fatalf("unexpected export:\n%s", buf.String())
+	}
+
+	value := From("de").Value("toppings").(arrayValue)
+	if text := value.exampleText(); text != "Käse, Salami und Oliven" {
+		t.Fatalf("unexpected example '%s'", text)
+	}
+
+	err := Import(AndroidImporter{}, "en", strings.NewReader(`<resources xmlns:tools="http://schemas.android.com/tools">
+    <string-array name="toppings" tools:list="and"><item>cheese</item></string-array>
+</resources>`))
+	if err == nil || !strings.Contains(err.Error(), "unknown list style 'and'") {
+		t.Fatalf("expected an unknown list style but got %v", err)
+	}
+}
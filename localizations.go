@@ -18,6 +18,7 @@ package i18n
 import (
 	"golang.org/x/text/language"
 	"sync"
+	"sync/atomic"
 )
 
 // maxLocaleCacheSize limits how many locales we cache. We want to avoid garbage and safe parsing time. However
@@ -26,77 +27,113 @@ const maxLocaleCacheSize = 1000
 
 // localizations is our internal implementation using CLDR rules for locales and plurals from
 // golang.org/x/text/* implementations.
-// All public methods are safe for concurrent usage.
+// All public methods are safe for concurrent usage. Reads are lock-free, because they work on an immutable
+// catalog snapshot. Writers are serialized and publish a modified copy.
 type localizations struct {
-	translationPriority []language.Tag
+	// parsedTagsCount is the first field, because the atomic 64-bit operations require a 64-bit alignment, which
+	// 32-bit platforms only guarantee for the first word of an allocated struct
+	parsedTagsCount int64
 
-	translations      map[language.Tag]*Resources
-	translationsMutex sync.RWMutex
+	published atomic.Value // published contains the immutable *catalog
+	mutex     sync.Mutex   // mutex serializes the writers
 
-	matcher language.Matcher
 	// we expect, that the amount of locales are a small number of finite tags
-	parsedTags      map[string]language.Tag
-	parsedTagsMutex sync.RWMutex
+	parsedTags atomic.Value // parsedTags contains the *sync.Map of locale strings to language.Tag
+}
+
+// catalog is an immutable snapshot of the configured translations
+type catalog struct {
+	translationPriority []language.Tag
+	translations        map[language.Tag]*Resources
+	matcher             language.Matcher
+	fallback            FallbackChain
 }
 
 func newLocalizations() *localizations {
-	return &localizations{
+	l := &localizations{}
+	l.published.Store(&catalog{
 		translations: make(map[language.Tag]*Resources),
-		parsedTags:   make(map[string]language.Tag),
+		matcher:      language.NewMatcher(nil),
+		fallback:     DefaultFallbackChain,
+	})
+	l.parsedTags.Store(&sync.Map{})
+
+	return l
+}
+
+// load returns the current immutable catalog
+func (l *localizations) load() *catalog {
+	return l.published.Load().(*catalog)
+}
+
+// update serializes the writers and publishes the modified copy of the current catalog.
+func (l *localizations) update(f func(c *catalog)) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	current := l.load()
+	c := &catalog{
+		translationPriority: append([]language.Tag(nil), current.translationPriority...),
+		translations:        make(map[language.Tag]*Resources, len(current.translations)),
+		matcher:             current.matcher,
+		fallback:            current.fallback,
+	}
+
+	for k, v := range current.translations {
+		c.translations[k] = v
 	}
+
+	f(c)
+	l.published.Store(c)
 }
 
 // SetTranslationPriority updates the resolution order and removes unwanted translations
 func (l *localizations) SetTranslationPriority(order []string) {
-	l.translationsMutex.Lock()
-	defer l.translationsMutex.Unlock()
-
-	var wanted []language.Tag
-	for _, ordered := range order {
-		wantedTag := language.Make(ordered)
-		for _, existingTag := range l.translationPriority {
-			if existingTag == wantedTag {
-				wanted = append(wanted, existingTag)
-				break
+	l.update(func(c *catalog) {
+		var wanted []language.Tag
+		for _, ordered := range order {
+			wantedTag := language.Make(ordered)
+			for _, existingTag := range c.translationPriority {
+				if existingTag == wantedTag {
+					wanted = append(wanted, existingTag)
+					break
+				}
 			}
 		}
-	}
 
-	// free memory for unwanted translations
-	for _, t := range l.translationPriority {
-		found := false
-		for _, w := range wanted {
-			if t == w {
-				found = true
-				break
+		// free memory for unwanted translations
+		for _, t := range c.translationPriority {
+			found := false
+			for _, w := range wanted {
+				if t == w {
+					found = true
+					break
+				}
+			}
+			if !found {
+				delete(c.translations, t)
 			}
 		}
-		if !found {
-			delete(l.translations, t)
-		}
-	}
-	l.translationPriority = wanted
+		c.translationPriority = wanted
+		c.matcher = language.NewMatcher(c.translationPriority)
+	})
 }
 
 // Locale parses the given unsafe locale into a valid BCP 47 tag.
 func (l *localizations) Locale(locale string) language.Tag {
-	l.parsedTagsMutex.RLock()
-	tag, exists := l.parsedTags[locale]
-	l.parsedTagsMutex.RUnlock()
-
-	if exists {
-		return tag
+	tags := l.parsedTags.Load().(*sync.Map)
+	if tag, exists := tags.Load(locale); exists {
+		return tag.(language.Tag)
 	}
 
-	l.parsedTagsMutex.Lock()
-	defer l.parsedTagsMutex.Unlock()
-
-	if len(l.parsedTags) > maxLocaleCacheSize {
-		l.parsedTags = make(map[string]language.Tag)
+	if atomic.AddInt64(&l.parsedTagsCount, 1) > maxLocaleCacheSize {
+		tags = &sync.Map{}
+		l.parsedTags.Store(tags)
+		atomic.StoreInt64(&l.parsedTagsCount, 0)
 	}
 
 	res := language.Make(locale)
-	l.parsedTags[locale] = res
+	tags.Store(locale, res)
 
 	return res
 }
@@ -104,21 +141,76 @@ func (l *localizations) Locale(locale string) language.Tag {
 // Configure allocates, if required, a new resource and returns it
 func (l *localizations) Configure(locale string) *Resources {
 	tag := l.Locale(locale)
-	l.translationsMutex.RLock()
-	res := l.translations[tag]
-	l.translationsMutex.RUnlock()
-
-	if res != nil {
+	if res := l.load().translations[tag]; res != nil {
 		return res
 	}
 
-	l.translationsMutex.Lock()
-	defer l.translationsMutex.Unlock()
+	var res *Resources
+
+	l.update(func(c *catalog) {
+		// another writer may have been faster
+		if res = c.translations[tag]; res != nil {
+			return
+		}
 
-	res = newResources(tag)
-	l.translations[tag] = res
-	l.translationPriority = append(l.translationPriority, tag)
-	l.matcher = language.NewMatcher(l.translationPriority)
+		res = newResources(tag)
+		res.parent = l
+		c.translations[tag] = res
+		c.translationPriority = append(c.translationPriority, tag)
+		c.matcher = language.NewMatcher(c.translationPriority)
+	})
+
+	return res
+}
+
+// Get returns the resources of exactly the given locale or nil, if not configured.
+func (l *localizations) Get(locale string) *Resources {
+	return l.load().translations[l.Locale(locale)]
+}
+
+// Resources returns all configured resources in translation priority
+func (l *localizations) Resources() []*Resources {
+	c := l.load()
+	res := make([]*Resources, 0, len(c.translationPriority))
+
+	for _, tag := range c.translationPriority {
+		res = append(res, c.translations[tag])
+	}
+
+	return res
+}
+
+// SetFallbackChain updates the chain, which is searched for missing keys. A nil chain disables the fallback.
+func (l *localizations) SetFallbackChain(chain FallbackChain) {
+	if chain == nil {
+		chain = NoFallbackChain
+	}
+
+	l.update(func(c *catalog) {
+		c.fallback = chain
+	})
+}
+
+// Fallbacks returns the available resources of the fallback chain for the given locale, without the locale itself
+// and without duplicates.
+func (l *localizations) Fallbacks(tag language.Tag) []*Resources {
+	c := l.load()
+
+	var res []*Resources
+
+	seen := map[language.Tag]bool{tag: true}
+
+	for _, t := range c.fallback(tag, c.translationPriority) {
+		if seen[t] {
+			continue
+		}
+
+		seen[t] = true
+
+		if r := c.translations[t]; r != nil {
+			res = append(res, r)
+		}
+	}
 
 	return res
 }
@@ -126,10 +218,18 @@ func (l *localizations) Configure(locale string) *Resources {
 // Returns the best matching resource. If no resources are available, panics because it is a programming error
 // to call Match without configuring.
 func (l *localizations) Match(locales ...string) *Resources {
-	l.translationsMutex.RLock()
-	defer l.translationsMutex.RUnlock()
+	res, _ := l.MatchConfidence(locales...)
 
-	if len(l.translationPriority) == 0 {
+	return res
+}
+
+// MatchConfidence returns the best matching resource and how sure the matcher is about it. If nothing matches at
+// all, the first resource in translation priority is returned with language.No. If no resources are available,
+// panics because it is a programming error to call Match without configuring.
+func (l *localizations) MatchConfidence(locales ...string) (*Resources, language.Confidence) {
+	c := l.load()
+
+	if len(c.translationPriority) == 0 {
 		panic("illegal state: not yet configured")
 	}
 
@@ -138,18 +238,13 @@ func (l *localizations) Match(locales ...string) *Resources {
 		tmp = append(tmp, l.Locale(locale))
 	}
 
-	bestTag, _, _ := l.matcher.Match(tmp...)
+	// the returned tag may contain extensions or regions of the wanted tag, but the index is always the supported one
+	_, index, confidence := c.matcher.Match(tmp...)
 
-	res := l.translations[bestTag]
+	res := c.translations[c.translationPriority[index]]
 	if res == nil {
-		if len(l.translations) == 0 {
-			panic("assert: may not be nil")
-		}
-
-		for _, r := range l.translations {
-			return r
-		}
+		panic("assert: may not be nil")
 	}
 
-	return res
+	return res, confidence
 }
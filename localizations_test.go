@@ -0,0 +1,71 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"errors"
+	"golang.org/x/text/language"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	bundle := NewBundle()
+	bundle.ImportValue(NewText("und", "a", "und"))
+	bundle.ImportValue(NewText("de", "a", "de"))
+	bundle.ImportValue(NewText("fr", "a", "fr"))
+
+	tests := []struct {
+		locales    []string
+		want       string
+		confidence language.Confidence
+	}{
+		{[]string{"de"}, "de", language.Exact},
+		{[]string{"de-AT"}, "de", language.High},
+		{[]string{"ja", "fr-CA"}, "fr", language.High},
+		{[]string{"ja"}, "und", language.No},
+		{nil, "und", language.No},
+	}
+
+	for _, tt := range tests {
+		// repeat to ensure a stable result
+		for i := 0; i < 10; i++ {
+			res, confidence := bundle.Match(tt.locales...)
+			if res.Locale() != tt.want || confidence != tt.confidence {
+				t.Fatalf("%v: expected %s (%v) but got %s (%v)", tt.locales, tt.want, tt.confidence, res.Locale(),
+					confidence)
+			}
+		}
+	}
+
+	if _, err := bundle.MatchStrict(language.High, "ja"); !errors.Is(err, ErrNoMatch) {
+		t.Fatalf("expected ErrNoMatch but got %v", err)
+	}
+
+	res, err := bundle.MatchStrict(language.High, "de-CH")
+	if err != nil || res.Locale() != "de" {
+		t.Fatalf("expected de but got %v", err)
+	}
+
+	if _, err := NewBundle().MatchStrict(language.No, "de"); !errors.Is(err, ErrNoMatch) {
+		t.Fatalf("expected ErrNoMatch but got %v", err)
+	}
+
+	// the matcher must follow the new priority
+	bundle.TranslationPriority("fr", "und")
+
+	if res, _ := bundle.Match("ja"); res.Locale() != "fr" {
+		t.Fatalf("expected fr but got %s", res.Locale())
+	}
+}
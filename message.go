@@ -0,0 +1,266 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"golang.org/x/text/language"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// buffers are reused by String, like the fmt package does for Sprintf
+// nolint: gochecknoglobals
+var buffers = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 128)
+		return &buf
+	},
+}
+
+// segment is either a literal text or a directive, which formats an argument
+type segment struct {
+	literal string
+	arg     int    // arg is the index of the formatted argument or -1 for a literal
+	verb    byte   // verb is the last character of the directive
+	plain   bool   // plain directives have no flags, width or precision, like %s or %[2]d
+	spec    string // spec is the directive without the argument index, like %5.2f
+}
+
+// A message is a printf template, which has been compiled once into literal and argument segments. Formatting
+// is a single append pass, which only falls back to the fmt package for directives with flags, width or precision
+// and for unusual arguments. Templates which cannot be compiled safely, are always formatted by fmt.Sprintf.
+//
+// Only numbers with the numeric verbs %d, %f and %F and fmt.Formatter arguments, like Currency, are localized. Any
+// other directive, especially %v, keeps the notation of fmt, so that codes or years do not become 12,345 or 2.020.
+type message struct {
+	src        string
+	segments   []segment
+	directives int            // directives is the amount of arguments, which fmt expects for a template without indices
+	reordered  bool           // reordered is true, if any directive uses an explicit argument index
+	sprintf    bool           // sprintf is true, if the template cannot be compiled safely
+	printer    *numberPrinter // printer localizes the numbers or is nil for the plain notation of fmt
+	numeric    map[int]bool   // numeric contains the indices of the arguments, which are formatted by a numeric verb
+}
+
+// compileMessage parses the template using the specifiers of ParsePrintf
+func compileMessage(str string) *message {
+	specs := ParsePrintf(str)
+	sort.Sort(pfsSortByPosIndex(specs))
+
+	msg := &message{src: str, numeric: numericArgs(specs)}
+	pos := 0
+
+	for _, spec := range specs {
+		// a * width or precision consumes arguments on its own and odd verbs print errors, which is left to fmt
+		if spec.BadIndex || spec.WidthArg >= 0 || spec.PrecisionArg >= 0 || !isLetter(spec.Verb()) ||
+			!msg.addLiteral(str[pos:spec.Pos]) {
+			return &message{src: str, directives: len(specs), sprintf: true, numeric: msg.numeric}
+		}
+
+		msg.reordered = msg.reordered || spec.Reordered
+		msg.segments = append(msg.segments, segment{
+			arg:   spec.Arg,
+			verb:  spec.Verb(),
+			plain: spec.Plain(),
+			spec:  spec.Unindexed(),
+		})
+		msg.directives++
+		pos = spec.End
+	}
+
+	if !msg.addLiteral(str[pos:]) {
+		return &message{src: str, directives: len(specs), sprintf: true, numeric: msg.numeric}
+	}
+
+	return msg
+}
+
+// numericArgs returns the indices of the arguments, which any directive formats with a numeric verb
+func numericArgs(specs []PrintfFormatSpecifier) map[int]bool {
+	var res map[int]bool
+
+	for _, spec := range specs {
+		if !spec.BadIndex && spec.Arg >= 0 && numericVerb(spec.Verb()) {
+			if res == nil {
+				res = make(map[int]bool)
+			}
+
+			res[spec.Arg] = true
+		}
+	}
+
+	return res
+}
+
+// numericVerb returns true for the verbs, whose numbers are localized
+func numericVerb(verb byte) bool {
+	return verb == 'd' || verb == 'f' || verb == 'F'
+}
+
+// compileLocalizedMessage compiles the template, whose numbers are formatted by the CLDR rules of the tag, like
+// 1.234,5 in german. The und locale keeps the plain notation of fmt.
+func compileLocalizedMessage(str string, tag language.Tag) *message {
+	msg := compileMessage(str)
+	if tag != language.Und {
+		msg.printer = printerOf(tag)
+	}
+
+	return msg
+}
+
+// addLiteral appends the unescaped text. Returns false, if the text contains a directive which has not been
+// understood.
+func (m *message) addLiteral(text string) bool {
+	if text == "" {
+		return true
+	}
+
+	if strings.Contains(strings.ReplaceAll(text, "%%", ""), "%") {
+		return false
+	}
+
+	m.segments = append(m.segments, segment{literal: strings.ReplaceAll(text, "%%", "%"), arg: -1})
+
+	return true
+}
+
+// hasArgs returns true, if the message formats any argument
+func (m *message) hasArgs() bool {
+	return m.directives > 0
+}
+
+// String formats the message like fmt.Sprintf
+func (m *message) String(args ...interface{}) string {
+	if m.sprintf {
+		return m.format(args)
+	}
+
+	// a plain text needs no copy
+	if len(m.segments) == 1 && m.segments[0].arg < 0 && len(args) == 0 {
+		return m.segments[0].literal
+	}
+
+	buf := buffers.Get().(*[]byte)
+	*buf = m.append((*buf)[:0], args)
+	res := string(*buf)
+	buffers.Put(buf)
+
+	return res
+}
+
+// append formats the message like fmt.Sprintf and appends it to dst
+func (m *message) append(dst []byte, args []interface{}) []byte {
+	// missing or superfluous arguments are reported by fmt in its own notation
+	if m.sprintf || !m.reordered && len(args) > m.directives {
+		return append(dst, m.format(args)...)
+	}
+
+	start := len(dst)
+
+	for i := range m.segments {
+		s := &m.segments[i]
+		if s.arg < 0 {
+			dst = append(dst, s.literal...)
+			continue
+		}
+
+		if s.arg >= len(args) {
+			return append(dst[:start], m.format(args)...)
+		}
+
+		dst = s.appendArg(dst, args[s.arg], m.printer)
+	}
+
+	return dst
+}
+
+// format formats the entire template by fmt, which also reports missing or superfluous arguments in its own
+// notation. Only the arguments of numeric verbs and fmt.Formatter arguments are localized by the printer.
+func (m *message) format(args []interface{}) string {
+	if m.printer == nil {
+		return fmt.Sprintf(m.src, args...)
+	}
+
+	tmp := make([]interface{}, len(args))
+	for i, arg := range args {
+		if _, ok := arg.(fmt.Formatter); ok || m.numeric[i] {
+			arg = localizedArg{arg: arg, printer: m.printer}
+		}
+
+		tmp[i] = arg
+	}
+
+	return fmt.Sprintf(m.src, tmp...)
+}
+
+// localizedArg formats its argument by the printer, which also passes the language to a fmt.Formatter
+type localizedArg struct {
+	arg     interface{}
+	printer *numberPrinter
+}
+
+// Format implements fmt.Formatter
+func (a localizedArg) Format(s fmt.State, verb rune) {
+	_, _ = io.WriteString(s, a.printer.sprintf(directive(s, verb), a.arg))
+}
+
+// appendArg formats the common types of plain directives without fmt. Localized integers take the shortcut only if
+// they look the same anyway, which are the small ones in most languages. Only numeric verbs and fmt.Formatter
+// arguments are formatted by the printer.
+func (s *segment) appendArg(dst []byte, arg interface{}, p *numberPrinter) []byte {
+	if _, ok := arg.(fmt.Formatter); !ok && !numericVerb(s.verb) {
+		p = nil
+	}
+
+	if s.plain {
+		switch v := arg.(type) {
+		case string:
+			if s.verb == 's' || s.verb == 'v' {
+				return append(dst, v...)
+			}
+		case int:
+			if (s.verb == 'd' || s.verb == 'v') && p.plain(int64(v)) {
+				return strconv.AppendInt(dst, int64(v), 10)
+			}
+		case int64:
+			if (s.verb == 'd' || s.verb == 'v') && p.plain(v) {
+				return strconv.AppendInt(dst, v, 10)
+			}
+		case int32:
+			if (s.verb == 'd' || s.verb == 'v') && p.plain(int64(v)) {
+				return strconv.AppendInt(dst, int64(v), 10)
+			}
+		case uint:
+			if (s.verb == 'd' || s.verb == 'v') && (p == nil || v < 1000 && p.plainIntegers) {
+				return strconv.AppendUint(dst, uint64(v), 10)
+			}
+		case uint64:
+			if (s.verb == 'd' || s.verb == 'v') && (p == nil || v < 1000 && p.plainIntegers) {
+				return strconv.AppendUint(dst, v, 10)
+			}
+		case float64:
+			if s.verb == 'f' && p == nil {
+				return strconv.AppendFloat(dst, v, 'f', 6, 64)
+			}
+		}
+	}
+
+	return append(dst, p.sprintf(s.spec, arg)...)
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCompileMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		tpl     string
+		args    []interface{}
+		sprintf bool
+	}{
+		{"plain", "hello world", nil, false},
+		{"string", "hello %s", []interface{}{"world"}, false},
+		{"int", "%d files", []interface{}{42}, false},
+		{"int64", "%d files", []interface{}{int64(-42)}, false},
+		{"uint", "%d files", []interface{}{uint(42)}, false},
+		{"float", "%f km", []interface{}{3.5}, false},
+		{"width", "%5.2f km and %-4s|", []interface{}{3.14159, "ab"}, false},
+		{"percent", "100%% of %s", []interface{}{"all"}, false},
+		{"percent only", "100%%", nil, false},
+		{"indexed", "%[2]s owns %[1]d cats", []interface{}{3, "Nick"}, false},
+		{"indexed repeated", "%[1]s and %[1]s", []interface{}{"x"}, false},
+		{"indexed continued", "%[2]s %s", []interface{}{"a", "b", "c"}, false},
+		{"wrong type", "%d files", []interface{}{"many"}, false},
+		{"string as d", "%s files", []interface{}{42}, false},
+		{"missing", "%s and %s", []interface{}{"one"}, false},
+		{"missing indexed", "%[3]s", []interface{}{"one"}, false},
+		{"extra", "%s", []interface{}{"one", 2}, false},
+		{"extra plain", "hello", []interface{}{"one"}, false},
+		{"v verb", "%v and %s", []interface{}{1, "b"}, false},
+		{"bad verb", "%z", []interface{}{1}, false},
+		{"space flag", "100% sure", nil, false},
+		{"star width", "%*d|", []interface{}{5, 42}, true},
+		{"no verb", "100%", nil, true},
+		{"percent with flags", "%-5%", nil, true},
+		{"bad index", "%[0]d", []interface{}{1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := compileMessage(tt.tpl)
+			if msg.sprintf != tt.sprintf {
+				t.Fatalf("expected sprintf %v but got %v", tt.sprintf, msg.sprintf)
+			}
+
+			expected := fmt.Sprintf(tt.tpl, tt.args...)
+			if got := msg.String(tt.args...); got != expected {
+				t.Fatalf("expected '%s' but got '%s'", expected, got)
+			}
+
+			if got := string(msg.append([]byte(">"), tt.args)); got != ">"+expected {
+				t.Fatalf("expected '>%s' but got '%s'", expected, got)
+			}
+		})
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	setup()
+
+	ImportValue(NewText("en", "greeting", "hello %s, you have %d messages"))
+	ImportValue(NewQuantityText("en", "files").Zero("no files").One("%d file").Other("%d files"))
+
+	res := From("en")
+
+	buf, err := res.AppendText(nil, "greeting", "Nick", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "hello Nick, you have 3 messages"; string(buf) != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, string(buf))
+	}
+
+	buf, err = res.AppendQuantityText(append(buf[:0], '>'), "files", 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := ">2 files"; string(buf) != expected {
+		t.Fatalf("expected '%s' but got '%s'", expected, string(buf))
+	}
+
+	if _, err := res.AppendText(nil, "missing"); err != ErrTextNotFound {
+		t.Fatalf("expected ErrTextNotFound but got %v", err)
+	}
+
+	name := "Nick"
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = res.AppendText(buf[:0], "greeting", name, 200)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("expected no allocations but got %v", allocs)
+	}
+}
@@ -0,0 +1,515 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/icu"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Args are the named arguments of an ICU MessageFormat text, like Args{"name": "Nick", "count": 3} for
+// "{name} has {count, plural, one {# cat} other {# cats}}". Pass them as the only argument to Text.
+type Args map[string]interface{}
+
+// A messageFormatValue is a Value in the ICU MessageFormat syntax, which supports named arguments and nested plurals,
+// ordinals and selects, see also https://unicode-org.github.io/icu/userguide/format_parse/messages/
+type messageFormatValue struct {
+	locale  string
+	Id      string
+	pattern string
+	tag     language.Tag
+	note    string
+	origin  Origin
+	parsed  icu.Message
+}
+
+// ErrMissingArgument indicates that an ICU message references an argument, which has not been passed
+type ErrMissingArgument struct {
+	Value Value
+	Name  string
+}
+
+func (e ErrMissingArgument) Error() string {
+	return located(fmt.Sprintf("the message %s.%s has no value for the argument '%s'", e.Value.Locale(), e.Value.ID(),
+		e.Name), e.Value, nil)
+}
+
+// NewMessageText parses the pattern in the ICU MessageFormat syntax, like
+//
+//	{name} has {count, plural, =0 {no cats} one {# cat} other {# cats}}
+//
+// The supported arguments are {name}, {n, number}, {n, plural, ...} with an optional offset and exact matches like =0,
+// {n, selectordinal, ...} and {g, select, ...}. Numbers are formatted by the CLDR rules of the locale. The number
+// styles are integer, percent, currency for the currency of the region, currency/EUR for an explicit one and
+// compact-short, also in the skeleton notation like {n, number, ::percent}.
+//
+// A time.Time is formatted by {d, date} or {d, time} with the style short, medium, long or full or a skeleton like
+// {d, date, ::yMMMd}. A time.Duration is formatted by {d, duration} like "2 hours, 5 minutes" or by
+// {d, duration, relative} like "in 2 hours".
+func NewMessageText(locale string, id string, pattern string) (Value, error) {
+	parsed, err := icu.Parse(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message format '%s': %w", id, err)
+	}
+
+	return messageFormatValue{
+		locale:  locale,
+		Id:      id,
+		pattern: pattern,
+		tag:     language.Make(locale),
+		parsed:  parsed,
+	}, nil
+}
+
+// MustMessageText is like NewMessageText but panics, if the pattern is invalid. It is used by the generated code.
+func MustMessageText(locale string, id string, pattern string) Value {
+	v, err := NewMessageText(locale, id, pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+func (m messageFormatValue) ID() string {
+	return m.Id
+}
+
+func (m messageFormatValue) Locale() string {
+	return m.locale
+}
+
+func (m messageFormatValue) Note() string {
+	return m.note
+}
+
+func (m messageFormatValue) Origin() Origin {
+	return m.origin
+}
+
+func (m messageFormatValue) updateTag(tag language.Tag) Value {
+	m.tag = tag
+	return m
+}
+
+// compile has nothing to do, because the pattern is already parsed by the constructor
+func (m messageFormatValue) compile() Value {
+	return m
+}
+
+// message returns the escaped pattern, which is not a printf template. Resources evaluate the parsed message instead.
+func (m messageFormatValue) message() *message {
+	return compileMessage(strings.ReplaceAll(m.pattern, "%", "%%"))
+}
+
+func (m messageFormatValue) quantityMessage(i, v, w, f, t int) (*message, bool) {
+	return m.message(), false
+}
+
+// TextArray returns the pattern within a one element array
+func (m messageFormatValue) TextArray() ([]string, error) {
+	return []string{m.pattern}, nil
+}
+
+// Text evaluates the message. The arguments are either a single Args or positional arguments, which are assigned to
+// the argument names in alphabetical order, just like the parameters of the generated accessors.
+func (m messageFormatValue) Text(args ...interface{}) (string, error) {
+	return m.text(m.named(args))
+}
+
+// QuantityText evaluates the message and uses the quantity for the first plural or ordinal argument, if not given
+func (m messageFormatValue) QuantityText(quantity int, args ...interface{}) (string, error) {
+	return m.text(m.withQuantity(quantity, args))
+}
+
+// withQuantity returns the named arguments, which contain the quantity for the first plural or ordinal argument
+func (m messageFormatValue) withQuantity(quantity int, args []interface{}) Args {
+	return m.with(m.named(args), quantity, icu.TypePlural, icu.TypeSelectOrdinal)
+}
+
+// QuantityTextDecimal evaluates the message and uses the quantity for the first plural argument, if not given
+func (m messageFormatValue) QuantityTextDecimal(quantity Decimal, args ...interface{}) (string, error) {
+	return m.text(m.with(m.named(args), quantity, icu.TypePlural))
+}
+
+// SelectText evaluates the message and uses the selector for the first select argument, if not given
+func (m messageFormatValue) SelectText(selector string, args ...interface{}) (string, error) {
+	return m.text(m.with(m.named(args), selector, icu.TypeSelect))
+}
+
+// arguments returns all arguments in alphabetical order of their names
+func (m messageFormatValue) arguments() []icu.ArgumentInfo {
+	args := m.parsed.Arguments()
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].Name < args[j].Name
+	})
+
+	return args
+}
+
+// argumentType returns the go type of an argument, which is also the parameter type of the generated accessor
+func argumentType(arg icu.ArgumentInfo) string {
+	switch arg.Type {
+	case icu.TypePlural, icu.TypeSelectOrdinal:
+		return "int"
+	case icu.TypeNumber:
+		if arg.Style == "integer" {
+			return "int"
+		}
+
+		return "float64"
+	case icu.TypeDate, icu.TypeTime:
+		return "time.Time"
+	case icu.TypeDuration:
+		return "time.Duration"
+	case icu.TypeSelect, "":
+		return "string"
+	default:
+		return "interface{}"
+	}
+}
+
+// named converts the arguments into Args, see also Text
+func (m messageFormatValue) named(args []interface{}) Args {
+	if len(args) == 1 {
+		switch t := args[0].(type) {
+		case Args:
+			return t
+		case map[string]interface{}:
+			return t
+		}
+	}
+
+	res := make(Args, len(args))
+	for i, arg := range m.arguments() {
+		if i < len(args) {
+			res[arg.Name] = args[i]
+		}
+	}
+
+	return res
+}
+
+// with returns a copy of args, which contains the value for the first argument of one of the given types, if that
+// has not been set already.
+func (m messageFormatValue) with(args Args, value interface{}, types ...string) Args {
+	for _, arg := range m.parsed.Arguments() {
+		for _, typ := range types {
+			if arg.Type != typ {
+				continue
+			}
+
+			if _, ok := args[arg.Name]; ok {
+				return args
+			}
+
+			res := make(Args, len(args)+1)
+			for k, v := range args {
+				res[k] = v
+			}
+
+			res[arg.Name] = value
+
+			return res
+		}
+	}
+
+	return args
+}
+
+// text evaluates the message, see also append
+func (m messageFormatValue) text(args Args) (string, error) {
+	dst, err := m.append(nil, args)
+
+	return string(dst), err
+}
+
+// append evaluates the message and appends it to dst. If the message references an argument, which is not contained
+// in args, the message is still appended but an ErrMissingArgument is returned.
+func (m messageFormatValue) append(dst []byte, args Args) ([]byte, error) {
+	dst = m.appendMessage(dst, m.parsed, args, "", printerOf(m.tag))
+
+	for _, arg := range m.arguments() {
+		if _, ok := args[arg.Name]; !ok {
+			return dst, ErrMissingArgument{Value: m, Name: arg.Name}
+		}
+	}
+
+	return dst, nil
+}
+
+// appendMessage evaluates the nodes. A # is replaced by pound, which is the formatted number of the innermost plural.
+// A missing argument is kept as {name}, so that it is obvious in the user interface.
+func (m messageFormatValue) appendMessage(dst []byte, msg icu.Message, args Args, pound string,
+	p *numberPrinter) []byte {
+	for _, node := range msg {
+		switch t := node.(type) {
+		case *icu.Text:
+			dst = append(dst, t.Value...)
+		case *icu.Pound:
+			dst = append(dst, pound...)
+		case *icu.Argument:
+			arg, ok := args[t.Name]
+			if !ok {
+				dst = append(dst, "{"+t.Name+"}"...)
+				continue
+			}
+
+			switch t.Type {
+			case icu.TypeNumber:
+				dst = m.appendNumber(dst, arg, t.Style, p)
+			case icu.TypeDate, icu.TypeTime:
+				dst = appendDateTime(dst, arg, t.Type, t.Style, p)
+			case icu.TypeDuration:
+				dst = appendDuration(dst, arg, t.Style, p)
+			default:
+				dst = appendArgument(dst, arg, p)
+			}
+		case *icu.Select:
+			dst = m.appendMessage(dst, icuCase(t.Cases, fmt.Sprint(args[t.Name])), args, pound, p)
+		case *icu.Plural:
+			if _, ok := args[t.Name]; !ok {
+				dst = m.appendMessage(dst, icuCase(t.Cases, other), args, "{"+t.Name+"}", p)
+				continue
+			}
+
+			c, number := m.pluralCase(t, args[t.Name], p)
+			dst = m.appendMessage(dst, c, args, number, p)
+		}
+	}
+
+	return dst
+}
+
+// pluralCase returns the exact match or the case of the plural category of the quantity minus the offset, together
+// with the formatted number for #. An invalid quantity selects the other case.
+func (m messageFormatValue) pluralCase(p *icu.Plural, quantity interface{}, printer *numberPrinter) (icu.Message,
+	string) {
+	d, ok := toDecimal(quantity)
+	if !ok {
+		return icuCase(p.Cases, other), fmt.Sprint(quantity)
+	}
+
+	value, _ := strconv.ParseFloat(d.String(), 64)
+
+	for _, c := range p.Cases {
+		if !strings.HasPrefix(c.Key, "=") {
+			continue
+		}
+
+		if exact, err := strconv.ParseFloat(c.Key[1:], 64); err == nil && exact == value {
+			return c.Message, printer.decimal(d)
+		}
+	}
+
+	if p.Offset != 0 {
+		d = NewDecimal(value-float64(p.Offset), len(d.fraction))
+	}
+
+	rules := plural.Cardinal
+	if p.Ordinal {
+		rules = plural.Ordinal
+	}
+
+	i, v, w, f, t := d.Operands()
+
+	return icuCase(p.Cases, formName(rules.MatchPlural(m.tag, i, v, w, f, t))), printer.decimal(d)
+}
+
+// icuCase returns the message of the case with the given key or the other case
+func icuCase(cases []icu.Case, key string) icu.Message {
+	for _, c := range cases {
+		if c.Key == key {
+			return c.Message
+		}
+	}
+
+	for _, c := range cases {
+		if c.Key == other {
+			return c.Message
+		}
+	}
+
+	return nil
+}
+
+// toDecimal converts the integers, floats, decimals and decimal strings into a Decimal
+func toDecimal(quantity interface{}) (Decimal, bool) {
+	switch t := quantity.(type) {
+	case Decimal:
+		return t, true
+	case int:
+		return intDecimal(int64(t)), true
+	case int8:
+		return intDecimal(int64(t)), true
+	case int16:
+		return intDecimal(int64(t)), true
+	case int32:
+		return intDecimal(int64(t)), true
+	case int64:
+		return intDecimal(t), true
+	case uint:
+		return uintDecimal(uint64(t)), true
+	case uint8:
+		return uintDecimal(uint64(t)), true
+	case uint16:
+		return uintDecimal(uint64(t)), true
+	case uint32:
+		return uintDecimal(uint64(t)), true
+	case uint64:
+		return uintDecimal(t), true
+	case float32:
+		return NewDecimal(float64(t), -1), !math.IsNaN(float64(t)) && !math.IsInf(float64(t), 0)
+	case float64:
+		return NewDecimal(t, -1), !math.IsNaN(t) && !math.IsInf(t, 0)
+	case string:
+		d, err := ParseDecimal(t)
+		return d, err == nil
+	default:
+		return Decimal{}, false
+	}
+}
+
+func intDecimal(n int64) Decimal {
+	if n < 0 {
+		// the negation of math.MinInt64 overflows, but not as an unsigned
+		d := uintDecimal(uint64(-(n + 1)) + 1)
+		d.negative = true
+
+		return d
+	}
+
+	return uintDecimal(uint64(n))
+}
+
+func uintDecimal(n uint64) Decimal {
+	return Decimal{integer: strings.TrimLeft(strconv.FormatUint(n, 10), "0")}
+}
+
+// appendArgument formats strings and small integers without fmt and anything else by the localized printer
+func appendArgument(dst []byte, arg interface{}, p *numberPrinter) []byte {
+	switch t := arg.(type) {
+	case string:
+		return append(dst, t...)
+	case int:
+		if p.plain(int64(t)) {
+			return strconv.AppendInt(dst, int64(t), 10)
+		}
+	case int64:
+		if p.plain(t) {
+			return strconv.AppendInt(dst, t, 10)
+		}
+	}
+
+	return append(dst, p.sprintf("%v", arg)...)
+}
+
+// appendNumber formats a {n, number, style} argument. Integers and decimals keep their digits and floats have at
+// most 3 fraction digits, unless the style says otherwise.
+func (m messageFormatValue) appendNumber(dst []byte, arg interface{}, style string, p *numberPrinter) []byte {
+	d, ok := toDecimal(arg)
+	if !ok {
+		return appendArgument(dst, arg, p)
+	}
+
+	value, _ := strconv.ParseFloat(d.String(), 64)
+
+	switch style = strings.TrimPrefix(style, "::"); {
+	case style == "integer" && len(d.fraction) > 0:
+		return append(dst, p.fixed(value, 0)...)
+	case style == "percent":
+		return append(dst, p.percent(value, defaultDigits)...)
+	case style == "compact-short":
+		return append(dst, p.compact(value, defaultDigits)...)
+	case style == "currency":
+		unit, _ := currency.FromTag(m.tag)
+		return append(dst, p.currency(value, unit.String(), defaultDigits)...)
+	case strings.HasPrefix(style, "currency/"):
+		return append(dst, p.currency(value, strings.TrimPrefix(style, "currency/"), defaultDigits)...)
+	}
+
+	switch arg.(type) {
+	case float32, float64:
+		return append(dst, p.float(value)...)
+	default:
+		return append(dst, p.decimal(d)...)
+	}
+}
+
+// appendDateTime formats a {d, date, style} or {d, time, style} argument. The default style is medium.
+func appendDateTime(dst []byte, arg interface{}, typ string, style string, p *numberPrinter) []byte {
+	t, ok := arg.(time.Time)
+	if !ok {
+		return appendArgument(dst, arg, p)
+	}
+
+	if strings.HasPrefix(style, "::") {
+		return append(dst, p.dateTime(Skeleton(t, style[2:]))...)
+	}
+
+	s := Medium
+
+	switch style {
+	case "short":
+		s = Short
+	case "long":
+		s = Long
+	case "full":
+		s = Full
+	}
+
+	if typ == icu.TypeTime {
+		return append(dst, p.dateTime(Time(t, s))...)
+	}
+
+	return append(dst, p.dateTime(Date(t, s))...)
+}
+
+// appendDuration formats a {d, duration} or {d, duration, relative} argument. Numbers are seconds.
+func appendDuration(dst []byte, arg interface{}, style string, p *numberPrinter) []byte {
+	var d time.Duration
+
+	switch t := arg.(type) {
+	case time.Duration:
+		d = t
+	case Duration:
+		d = time.Duration(t)
+	case RelativeTime:
+		d = time.Duration(t)
+	default:
+		seconds, ok := toDecimal(arg)
+		if !ok {
+			return appendArgument(dst, arg, p)
+		}
+
+		f, _ := strconv.ParseFloat(seconds.String(), 64)
+		d = time.Duration(f * float64(time.Second))
+	}
+
+	if style == "relative" {
+		return append(dst, p.relative(d)...)
+	}
+
+	return append(dst, p.duration(d)...)
+}
@@ -0,0 +1,190 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"errors"
+	"golang.org/x/text/language"
+	"strings"
+	"testing"
+)
+
+func TestMessageText(t *testing.T) {
+	const (
+		cats    = "{name} has {count, plural, =0 {no cats} one {# cat} other {# cats}}"
+		guests  = "{n, plural, offset:1 =0 {nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}"
+		nested  = "{g, select, female {{n, plural, one {she has a cat} other {she has # cats}}} other {{n, number} cats}}"
+		ordinal = "{n, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}"
+		hours   = "{n, plural, one {# час} few {# часа} many {# часов} other {# часа}}"
+	)
+
+	tests := []struct {
+		locale  string
+		pattern string
+		args    []interface{}
+		want    string
+	}{
+		{"en", cats, []interface{}{Args{"name": "Nick", "count": 0}}, "Nick has no cats"},
+		{"en", cats, []interface{}{Args{"name": "Nick", "count": 1}}, "Nick has 1 cat"},
+		{"en", cats, []interface{}{Args{"name": "Nick", "count": int64(5)}}, "Nick has 5 cats"},
+		{"en", cats, []interface{}{Args{"name": "Nick", "count": "1.0"}}, "Nick has 1.0 cats"},
+		{"en", cats, []interface{}{3, "Nick"}, "Nick has 3 cats"},
+		{"en", guests, []interface{}{Args{"host": "Anna", "n": 0}}, "nobody"},
+		{"en", guests, []interface{}{Args{"host": "Anna", "n": 1}}, "Anna"},
+		{"en", guests, []interface{}{Args{"host": "Anna", "n": 2}}, "Anna and 1 other"},
+		{"en", guests, []interface{}{Args{"host": "Anna", "n": 3}}, "Anna and 2 others"},
+		{"en", nested, []interface{}{Args{"g": "female", "n": 1}}, "she has a cat"},
+		{"en", nested, []interface{}{Args{"g": "female", "n": uint8(2)}}, "she has 2 cats"},
+		{"en", nested, []interface{}{Args{"g": "male", "n": 2.5}}, "2.5 cats"},
+		{"en", ordinal, []interface{}{Args{"n": 1}}, "1st"},
+		{"en", ordinal, []interface{}{Args{"n": 22}}, "22nd"},
+		{"en", ordinal, []interface{}{Args{"n": 13}}, "13th"},
+		{"ru", hours, []interface{}{Args{"n": 21}}, "21 час"},
+		{"ru", hours, []interface{}{Args{"n": 5}}, "5 часов"},
+		{"ru", hours, []interface{}{Args{"n": NewDecimal(1.5, 1)}}, "1,5 часа"},
+		{"ru", hours, []interface{}{Args{"n": "invalid"}}, "invalid часа"},
+		{"de", "it''s '{literal}' for {name}", []interface{}{Args{"name": "Nick"}}, "it's {literal} for Nick"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+": "+tt.pattern, func(t *testing.T) {
+			val, err := NewMessageText(tt.locale, "id", tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			str, err := val.updateTag(language.Make(tt.locale)).Text(tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+
+	if _, err := NewMessageText("en", "id", "{n, plural, one {# cat}}"); err == nil {
+		t.Fatal("expected an error for the missing other case")
+	}
+}
+
+func TestMessageTextMissingArgument(t *testing.T) {
+	const cats = "{name} has {count, plural, =0 {no cats} one {# cat} other {# cats}}"
+
+	tests := []struct {
+		pattern string
+		args    []interface{}
+		want    string
+		missing string
+	}{
+		{cats, []interface{}{map[string]interface{}{"count": 1}}, "{name} has 1 cat", "name"},
+		{cats, []interface{}{Args{"name": "Nick"}}, "Nick has {count} cats", "count"},
+		{"{who} und {n, plural, one {# weiterer} other {# weitere}}", nil, "{who} und {n} weitere", "n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			val := MustMessageText("de", "m", tt.pattern)
+
+			str, err := val.Text(tt.args...)
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+
+			var missing ErrMissingArgument
+			if !errors.As(err, &missing) || missing.Name != tt.missing {
+				t.Fatalf("expected the missing argument '%s' but got %v", tt.missing, err)
+			}
+		})
+	}
+
+	setup()
+	ImportValue(MustMessageText("de", "m", "{who} und {n, plural, one {# weiterer} other {# weitere}}"))
+
+	if _, err := From("de").AppendText(nil, "m"); err == nil {
+		t.Fatal("expected an error for the missing arguments")
+	}
+}
+
+func TestMessageTextResources(t *testing.T) {
+	setup()
+
+	ImportValue(MustMessageText("en", "x_has_cats",
+		"{name} has {count, plural, one {# cat} other {# cats}} and {g, select, female {her} other {their}} dog"))
+
+	res := From("en")
+
+	str, err := res.QuantityText("x_has_cats", 2, Args{"name": "Nick", "g": "female"})
+	if err != nil || str != "Nick has 2 cats and her dog" {
+		t.Fatalf("unexpected '%s': %v", str, err)
+	}
+
+	// the explicit argument wins over the quantity
+	str, err = res.QuantityText("x_has_cats", 2, Args{"name": "Nick", "count": 1, "g": "male"})
+	if err != nil || str != "Nick has 1 cat and their dog" {
+		t.Fatalf("unexpected '%s': %v", str, err)
+	}
+
+	str, err = res.SelectText("x_has_cats", "female", Args{"name": "Nick", "count": 1})
+	if err != nil || str != "Nick has 1 cat and her dog" {
+		t.Fatalf("unexpected '%s': %v", str, err)
+	}
+
+	buf, err := res.AppendText([]byte("> "), "x_has_cats", Args{"name": "Nick", "count": 3, "g": "x"})
+	if err != nil || string(buf) != "> Nick has 3 cats and their dog" {
+		t.Fatalf("unexpected '%s': %v", buf, err)
+	}
+
+	buf, err = res.AppendQuantityText(nil, "x_has_cats", 1, Args{"name": "Nick", "g": "x"})
+	if err != nil || !strings.HasPrefix(string(buf), "Nick has 1 cat and") {
+		t.Fatalf("unexpected '%s': %v", buf, err)
+	}
+}
+
+func TestValidateMessageText(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+	}{
+		{"valid", "{count, plural, other {{name} hat # Katzen}}", ""},
+		{"integer", "{name} hat {count, number, integer} Katzen", ""},
+		{"missing", "{name} hat Katzen", "the argument 'count' of en.x is a int but in de.x it is missing"},
+		{"extra", "{name} hat {count, plural, other {#}} Katzen von {owner}", "'owner' of en.x is missing"},
+		{"type", "{name} hat {count, number} Katzen", "the argument 'count' of en.x is a int but in de.x it is a float64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			ImportValue(MustMessageText("en", "x", "{name} has {count, plural, one {# cat} other {# cats}}"))
+			ImportValue(MustMessageText("de", "x", tt.pattern))
+
+			err := Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected '%s' but got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/internal"
+	"golang.org/x/text/language"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// ModuleOptions select the strings*.xml files of a module and name the generated files
+type ModuleOptions struct {
+	// Dir is the module root. The module of the current working directory is used by default.
+	Dir string
+
+	// Include contains glob patterns like "internal/*" or "strings-de*.xml". If given, one of them must match the
+	// path of a strings file relative to Dir, its name or one of its parent directories.
+	Include []string
+
+	// Exclude contains glob patterns like the Include patterns, which ignore the matching strings files
+	Exclude []string
+
+	// Output is the name of the generated file in each package, strings_gen.go by default
+	Output string
+}
+
+// withDefaults returns the options with the current module and the default output name, if not set
+func (o ModuleOptions) withDefaults() (ModuleOptions, error) {
+	if o.Dir == "" {
+		dir, err := internal.ModRootDir()
+		if err != nil {
+			return o, fmt.Errorf("unable to get current working directory: %w", err)
+		}
+
+		o.Dir = dir
+	}
+
+	if o.Output == "" {
+		o.Output = "strings_gen.go"
+	}
+
+	for _, pattern := range append(append([]string(nil), o.Include...), o.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return o, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+	}
+
+	return o, nil
+}
+
+// selected applies the include and exclude patterns to the file name
+func (o ModuleOptions) selected(fname string) bool {
+	rel, err := filepath.Rel(o.Dir, fname)
+	if err != nil {
+		return false
+	}
+
+	rel = filepath.ToSlash(rel)
+	if matchesAny(o.Exclude, rel) {
+		return false
+	}
+
+	return len(o.Include) == 0 || matchesAny(o.Include, rel)
+}
+
+// matchesAny checks the relative path, its name and each of its parent directories
+func matchesAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+
+		for p := rel; p != "." && p != "/"; p = path.Dir(p) {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Translations contains the values of all strings*.xml files of a module, which are found just like the generator
+// finds them. Besides generating the accessors, it checks calls with literal keys, like res.Text("hello_x", name),
+// which are not type safe in contrast to the generated accessors. See also the lint package, which provides that
+// check as a go vet analyzer.
+type Translations struct {
+	opts         ModuleOptions
+	translations []*packageTranslation
+	values       map[string]Value
+}
+
+// ScanTranslations loads all strings*.xml files of the package in dir and of its sub packages
+func ScanTranslations(dir string) (*Translations, error) {
+	return Scan(ModuleOptions{Dir: dir})
+}
+
+// Scan loads the selected strings*.xml files of the module
+func Scan(opts ModuleOptions) (*Translations, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	gen := newGoGeneratorWith(opts)
+	if err := gen.Scan(); err != nil {
+		return nil, fmt.Errorf("cannot scan module: %w", err)
+	}
+
+	t := &Translations{opts: opts, translations: gen.translations, values: make(map[string]Value)}
+
+	// the values are consistent across the locales, if they are valid, so the first file of a package wins
+	for _, translation := range t.translations {
+		for _, file := range translation.files {
+			for key, value := range file.values.load() {
+				if _, ok := t.values[key]; !ok {
+					t.values[key] = value
+				}
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// Keys returns all keys of all packages in sorted order
+func (t *Translations) Keys() []string {
+	return sortedKeys(t.values)
+}
+
+// Value returns the value of any locale for the key or nil
+func (t *Translations) Value(key string) Value {
+	return t.values[key]
+}
+
+// Files returns the names of all scanned strings*.xml files
+func (t *Translations) Files() []string {
+	var res []string
+
+	for _, translation := range t.translations {
+		for _, file := range translation.files {
+			res = append(res, file.filename)
+		}
+	}
+
+	return res
+}
+
+// Validate checks the consistency of the locales of each package, just like the generator does, but without
+// writing any file. The errors of all packages are returned as ErrList, each one starts with the file:line:col of
+// the offending value.
+func (t *Translations) Validate() error {
+	var errs []error
+
+	for _, translation := range t.translations {
+		err := translation.validate()
+		if list, ok := err.(ErrList); ok {
+			errs = append(errs, list.Errs...)
+		} else if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", translation.pkg.Dir, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return ErrList{Errs: errs}
+	}
+
+	return nil
+}
+
+// Emit validates each package and writes its generated accessors
+func (t *Translations) Emit() error {
+	for _, translation := range t.translations {
+		if err := translation.Emit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Bundle returns a new bundle, which contains the values of all scanned files. The default locale is imported
+// first, so that it is the fallback.
+func (t *Translations) Bundle() *Bundle {
+	var defaults, others []Value
+
+	for _, translation := range t.translations {
+		for _, file := range translation.files {
+			for _, key := range file.values.Keys() {
+				if file.values.tag == language.Und {
+					defaults = append(defaults, file.values.Value(key))
+				} else {
+					others = append(others, file.values.Value(key))
+				}
+			}
+		}
+	}
+
+	bundle := NewBundle()
+	bundle.ImportValues(defaults...)
+	bundle.ImportValues(others...)
+
+	return bundle
+}
+
+// LocaleStats contains the completeness of a locale across all packages
+type LocaleStats struct {
+	Locale     string
+	Translated int // Translated is the amount of keys, which the locale contains
+	Total      int // Total is the amount of keys of all locales of the packages
+}
+
+// Percent returns the translated keys in percent of all keys
+func (s LocaleStats) Percent() float64 {
+	if s.Total == 0 {
+		return 100
+	}
+
+	return float64(s.Translated) * 100 / float64(s.Total)
+}
+
+// Stats returns the completeness of each locale sorted by the locale. A package without a file for a locale counts
+// as entirely untranslated.
+func (t *Translations) Stats() []LocaleStats {
+	stats := make(map[string]*LocaleStats)
+
+	for _, translation := range t.translations {
+		for _, file := range translation.files {
+			stats[file.values.Locale()] = &LocaleStats{Locale: file.values.Locale()}
+		}
+	}
+
+	for _, translation := range t.translations {
+		total := len(translation.collectValues())
+		for _, s := range stats {
+			s.Total += total
+		}
+
+		for _, file := range translation.files {
+			stats[file.values.Locale()].Translated += len(file.values.Keys())
+		}
+	}
+
+	res := make([]LocaleStats, 0, len(stats))
+	for _, s := range stats {
+		res = append(res, *s)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Locale < res[j].Locale
+	})
+
+	return res
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeModule writes the files into a new temporary directory
+func writeModule(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "module")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		fname := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fname), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(fname, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestScan(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main.go":               "package main\n",
+		"strings.xml":           `<resources><string name="a">a</string><string name="b">b</string></resources>`,
+		"strings-de.xml":        `<resources><string name="a">A</string></resources>`,
+		"sub/sub.go":            "package sub\n",
+		"sub/strings.xml":       `<resources><string name="c">%s</string></resources>`,
+		"sub/strings-fr.xml":    `<resources><string name="c">%d</string></resources>`,
+		"legacy/legacy.go":      "package legacy\n",
+		"legacy/strings-de.xml": `<resources><string name="d">%s</string></resources>`,
+	})
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	tests := []struct {
+		name    string
+		opts    ModuleOptions
+		keys    []string
+		stats   []LocaleStats
+		invalid bool
+	}{
+		{
+			name: "all",
+			opts: ModuleOptions{Dir: dir},
+			keys: []string{"a", "b", "c", "d"},
+			stats: []LocaleStats{
+				{Locale: "de", Translated: 2, Total: 4},
+				{Locale: "fr", Translated: 1, Total: 4},
+				{Locale: "und", Translated: 3, Total: 4},
+			},
+			invalid: true,
+		},
+		{
+			name: "exclude directory",
+			opts: ModuleOptions{Dir: dir, Exclude: []string{"legacy"}},
+			keys: []string{"a", "b", "c"},
+			stats: []LocaleStats{
+				{Locale: "de", Translated: 1, Total: 3},
+				{Locale: "fr", Translated: 1, Total: 3},
+				{Locale: "und", Translated: 3, Total: 3},
+			},
+			invalid: true,
+		},
+		{
+			name:    "include directory",
+			opts:    ModuleOptions{Dir: dir, Include: []string{"sub/*"}},
+			keys:    []string{"c"},
+			stats:   []LocaleStats{{Locale: "fr", Translated: 1, Total: 1}, {Locale: "und", Translated: 1, Total: 1}},
+			invalid: true,
+		},
+		{
+			name:  "exclude name",
+			opts:  ModuleOptions{Dir: dir, Exclude: []string{"strings-??.xml"}},
+			keys:  []string{"a", "b", "c"},
+			stats: []LocaleStats{{Locale: "und", Translated: 3, Total: 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translations, err := Scan(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if keys := translations.Keys(); !reflect.DeepEqual(keys, tt.keys) {
+				t.Fatalf("expected keys %v but got %v", tt.keys, keys)
+			}
+
+			if stats := translations.Stats(); !reflect.DeepEqual(stats, tt.stats) {
+				t.Fatalf("expected stats %+v but got %+v", tt.stats, stats)
+			}
+
+			if err := translations.Validate(); (err != nil) != tt.invalid {
+				t.Fatalf("unexpected validation result: %v", err)
+			}
+		})
+	}
+
+	if _, err := Scan(ModuleOptions{Dir: dir, Include: []string{"["}}); err == nil {
+		t.Fatal("expected an invalid pattern")
+	}
+
+	translations, err := Scan(ModuleOptions{Dir: dir, Include: []string{"strings*.xml"}, Exclude: []string{"legacy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := translations.Bundle()
+	if str, err := bundle.From("de").Text("b"); err != nil || str != "b" {
+		t.Fatalf("expected the default locale as fallback but got '%s': %v", str, err)
+	}
+
+	if percent := (LocaleStats{Translated: 1, Total: 3}).Percent(); percent < 33.3 || percent > 33.4 {
+		t.Fatalf("unexpected percent %f", percent)
+	}
+}
+
+func TestValidateOrigin(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"main.go":        "package main\n",
+		"strings.xml":    "<resources>\n    <string name=\"a\">a</string>\n    <string name=\"b\">%s</string>\n</resources>",
+		"strings-de.xml": "<resources>\n\t<string name=\"a\">A</string> <string name=\"b\">%d</string>\n</resources>",
+	})
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	translations, err := Scan(ModuleOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = translations.Validate()
+	if err == nil {
+		t.Fatal("expected a verb conflict")
+	}
+
+	errs := err.(ErrList).Errs
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error but got %v", err)
+	}
+
+	msg := errs[0].Error()
+	en, de := filepath.Join(dir, "strings.xml")+":3:5", filepath.Join(dir, "strings-de.xml")+":2:30"
+
+	if !strings.HasPrefix(msg, en+": ") && !strings.HasPrefix(msg, de+": ") {
+		t.Fatalf("expected the origin as prefix but got %s", msg)
+	}
+
+	if !strings.Contains(msg, en) || !strings.Contains(msg, de) {
+		t.Fatalf("expected both origins but got %s", msg)
+	}
+}
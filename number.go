@@ -0,0 +1,364 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	xmessage "golang.org/x/text/message"
+	xcatalog "golang.org/x/text/message/catalog"
+	"golang.org/x/text/number"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// A Currency is an amount of money with its ISO 4217 code, like EUR or USD. It is formatted by the CLDR rules of the
+// message locale with the standard fraction digits of the currency, like "€1,234.50" in english or "1.234,50 €"
+// in german. Use it with %s or %v. A precision overrides the fraction digits and a width pads the text, like %-12.0v.
+type Currency struct {
+	Amount float64
+	Code   string
+}
+
+// Format implements fmt.Formatter
+func (c Currency) Format(s fmt.State, verb rune) {
+	pad(s, printerOf(languageOf(s)).currency(c.Amount, c.Code, precision(s)))
+}
+
+// A Percent is a ratio, which is formatted as percentage by the CLDR rules of the message locale, like 0.25 as "25%"
+// in english or "25 %" in german. Use it with %s or %v. A precision sets the fraction digits, like %.1v for "25.6%",
+// and a width pads the text.
+type Percent float64
+
+// Format implements fmt.Formatter
+func (p Percent) Format(s fmt.State, verb rune) {
+	pad(s, printerOf(languageOf(s)).percent(float64(p), precision(s)))
+}
+
+// A Compact is a number, which is formatted in the short compact form of the message locale, like 1200 as "1.2K" in
+// english or 3400000 as "3,4 Mio." in german. Languages without known abbreviations just group the digits.
+// Use it with %s or %v. A precision sets the fraction digits of the abbreviated number, like %.2v for "1.23K", and a
+// width pads the text.
+type Compact float64
+
+// Format implements fmt.Formatter
+func (c Compact) Format(s fmt.State, verb rune) {
+	pad(s, printerOf(languageOf(s)).compact(float64(c), precision(s)))
+}
+
+// Format implements fmt.Formatter and formats the decimal with its visible fraction digits by the CLDR rules of the
+// message locale, like 1.50 as "1,50" in german. A precision overrides the fraction digits and a width pads the text.
+func (d Decimal) Format(s fmt.State, verb rune) {
+	p := printerOf(languageOf(s))
+	if digits := precision(s); digits != defaultDigits {
+		f, _ := strconv.ParseFloat(d.String(), 64)
+		pad(s, p.fixed(f, digits))
+
+		return
+	}
+
+	pad(s, p.decimal(d))
+}
+
+// defaultDigits selects the default fraction digits of a number format
+const defaultDigits = -1
+
+// precision returns the precision of the directive, like 2 for %.2v, or defaultDigits
+func precision(s fmt.State) int {
+	if digits, ok := s.Precision(); ok {
+		return digits
+	}
+
+	return defaultDigits
+}
+
+// pad writes the text and honors the width and the - flag of the directive, like %10v or %-10v
+func pad(s fmt.State, text string) {
+	width, ok := s.Width()
+	if n := utf8.RuneCountInString(text); ok && n < width {
+		if s.Flag('-') {
+			text += strings.Repeat(" ", width-n)
+		} else {
+			text = strings.Repeat(" ", width-n) + text
+		}
+	}
+
+	_, _ = io.WriteString(s, text)
+}
+
+// directive rebuilds the unindexed directive, like %-10.2f, from the state of a fmt.Formatter
+func directive(s fmt.State, verb rune) string {
+	buf := []byte{'%'}
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			buf = append(buf, byte(flag))
+		}
+	}
+
+	if width, ok := s.Width(); ok {
+		buf = strconv.AppendInt(buf, int64(width), 10)
+	}
+
+	if digits, ok := s.Precision(); ok {
+		buf = strconv.AppendInt(append(buf, '.'), int64(digits), 10)
+	}
+
+	return string(append(buf, string(verb)...))
+}
+
+// languageOf returns the language of the printer of golang.org/x/text/message or und for the fmt package
+func languageOf(s fmt.State) language.Tag {
+	if l, ok := s.(interface{ Language() language.Tag }); ok {
+		return l.Language()
+	}
+
+	return language.Und
+}
+
+// printers caches a numberPrinter for each language tag
+// nolint: gochecknoglobals
+var printers sync.Map
+
+// emptyCatalog avoids, that our templates are translated again by the default catalog of golang.org/x/text/message
+// nolint: gochecknoglobals
+var emptyCatalog = xcatalog.NewBuilder()
+
+// A numberPrinter formats numbers by the CLDR rules of a language, like 1.234,5 in german
+type numberPrinter struct {
+	printer *xmessage.Printer
+	tag     language.Tag
+	minus   string // minus is the localized sign of negative numbers
+
+	// plainIntegers is true, if the integers below 1000 look just like formatted by strconv, which is not true
+	// for languages with other digits, like arabic.
+	plainIntegers bool
+}
+
+// printerOf returns the cached numberPrinter of the tag
+func printerOf(tag language.Tag) *numberPrinter {
+	if p, ok := printers.Load(tag); ok {
+		return p.(*numberPrinter)
+	}
+
+	p := &numberPrinter{
+		printer: xmessage.NewPrinter(tag, xmessage.Catalog(emptyCatalog)),
+		tag:     tag,
+	}
+
+	p.plainIntegers = p.printer.Sprintf("%d", -999) == "-999"
+	p.minus = strings.TrimSuffix(p.printer.Sprint(number.Decimal(-1)), p.printer.Sprint(number.Decimal(1)))
+
+	actual, _ := printers.LoadOrStore(tag, p)
+
+	return actual.(*numberPrinter)
+}
+
+// plain returns true, if the integer looks just like formatted by strconv. A nil printer is always plain.
+func (p *numberPrinter) plain(v int64) bool {
+	return p == nil || p.plainIntegers && v > -1000 && v < 1000
+}
+
+// sprintf formats like fmt.Sprintf but with localized numbers. A nil printer just uses fmt.
+func (p *numberPrinter) sprintf(format string, args ...interface{}) string {
+	if p == nil {
+		return fmt.Sprintf(format, args...)
+	}
+
+	// the printer keeps the arguments, so a copy avoids that the callers slice escapes on the fast path
+	return p.printer.Sprintf(format, append([]interface{}(nil), args...)...)
+}
+
+// decimal formats the decimal with its visible fraction digits
+func (p *numberPrinter) decimal(d Decimal) string {
+	if d.fraction == "" {
+		if i, err := strconv.ParseInt(d.String(), 10, 64); err == nil {
+			return p.printer.Sprint(number.Decimal(i))
+		}
+	}
+
+	f, _ := strconv.ParseFloat(d.String(), 64)
+
+	return p.fixed(f, len(d.fraction))
+}
+
+// fixed formats the float with exactly the given fraction digits
+func (p *numberPrinter) fixed(f float64, fractionDigits int) string {
+	return p.printer.Sprint(number.Decimal(f, number.MinFractionDigits(fractionDigits),
+		number.MaxFractionDigits(fractionDigits)))
+}
+
+// float formats the float with at most 3 fraction digits
+func (p *numberPrinter) float(f float64) string {
+	return p.printer.Sprint(number.Decimal(f))
+}
+
+// percent formats the ratio as percentage with the given or the default fraction digits
+func (p *numberPrinter) percent(f float64, fractionDigits int) string {
+	if fractionDigits != defaultDigits {
+		return p.printer.Sprint(number.Percent(f, number.MinFractionDigits(fractionDigits),
+			number.MaxFractionDigits(fractionDigits)))
+	}
+
+	return p.printer.Sprint(number.Percent(f))
+}
+
+// currencyPattern tells where the currency symbol belongs to
+type currencyPattern struct {
+	suffix bool // suffix is true for patterns like "#,##0.00 ¤"
+	space  bool // space separates the symbol from the number
+}
+
+// currencyPatterns are the CLDR standard currency patterns of languages or regions, which do not put the symbol
+// just in front of the number, like "¤#,##0.00" in english.
+// nolint: gochecknoglobals
+var currencyPatterns = map[string]currencyPattern{
+	"bg": {true, true}, "ca": {true, true}, "cs": {true, true}, "da": {true, true}, "de": {true, true},
+	"el": {true, true}, "es": {true, true}, "et": {true, true}, "fi": {true, true}, "fr": {true, true},
+	"hr": {true, true}, "hu": {true, true}, "it": {true, true}, "lt": {true, true}, "lv": {true, true},
+	"nb": {true, true}, "pl": {true, true}, "ro": {true, true}, "ru": {true, true}, "sk": {true, true},
+	"sl": {true, true}, "sv": {true, true}, "uk": {true, true}, "pt-PT": {true, true},
+	"nl": {false, true}, "pt": {false, true}, "de-AT": {false, true}, "de-CH": {false, true}, "it-CH": {false, true},
+	"es-419": {false, false}, "es-MX": {false, false}, "es-US": {false, false},
+}
+
+// currency formats the amount with the given or the standard fraction digits and the symbol of the currency. An
+// unknown currency code is appended to the amount.
+func (p *numberPrinter) currency(amount float64, code string, fractionDigits int) string {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		if fractionDigits == defaultDigits {
+			fractionDigits = 2
+		}
+
+		return p.fixed(amount, fractionDigits) + " " + code
+	}
+
+	scale, _ := currency.Standard.Rounding(unit)
+	if fractionDigits != defaultDigits {
+		scale = fractionDigits
+	}
+
+	text := p.fixed(math.Abs(amount), scale)
+	symbol := p.printer.Sprint(currency.Symbol(unit))
+	pattern := p.lookupCurrencyPattern()
+
+	sep := ""
+	if pattern.space {
+		sep = " "
+	}
+
+	if pattern.suffix {
+		text = text + sep + symbol
+	} else {
+		text = symbol + sep + text
+	}
+
+	if amount < 0 {
+		return p.minus + text
+	}
+
+	return text
+}
+
+// lookupCurrencyPattern returns the pattern of the region or the language
+func (p *numberPrinter) lookupCurrencyPattern() currencyPattern {
+	base, _ := p.tag.Base()
+	if region, confidence := p.tag.Region(); confidence == language.Exact {
+		if pattern, ok := currencyPatterns[base.String()+"-"+region.String()]; ok {
+			return pattern
+		}
+	}
+
+	return currencyPatterns[base.String()]
+}
+
+// compactUnit is a power of ten with its abbreviation in the CLDR short compact decimal format
+type compactUnit struct {
+	value  float64
+	suffix string
+}
+
+// compactUnits are the short compact forms of some languages. Unknown languages group the digits without abbreviation.
+// nolint: gochecknoglobals
+var compactUnits = map[string][]compactUnit{
+	"und": {{1e3, "K"}, {1e6, "M"}, {1e9, "G"}, {1e12, "T"}},
+	"en":  {{1e3, "K"}, {1e6, "M"}, {1e9, "B"}, {1e12, "T"}},
+	"de":  {{1e6, " Mio."}, {1e9, " Mrd."}, {1e12, " Bio."}},
+	"fr":  {{1e3, " k"}, {1e6, " M"}, {1e9, " Md"}, {1e12, " Bn"}},
+	"es":  {{1e3, " mil"}, {1e6, " M"}, {1e12, " B"}},
+	"it":  {{1e6, " Mln"}, {1e9, " Mrd"}, {1e12, " Bln"}},
+	"ja":  {{1e4, "万"}, {1e8, "億"}, {1e12, "兆"}},
+	"zh":  {{1e4, "万"}, {1e8, "亿"}, {1e12, "万亿"}},
+}
+
+// compact abbreviates the number by its largest unit and keeps 2 significant digits for small values, like 1.2K,
+// 12K or 123K, unless the fraction digits are given.
+func (p *numberPrinter) compact(f float64, fractionDigits int) string {
+	base, _ := p.tag.Base()
+	units := compactUnits[base.String()]
+
+	for i := len(units) - 1; i >= 0; i-- {
+		scaled := math.Abs(f) / units[i].value
+		if scaled < 1 {
+			continue
+		}
+
+		digits := fractionDigits
+		if digits == defaultDigits {
+			digits = 0
+			if scaled < 10 {
+				digits = 1
+			}
+		}
+
+		// a rounded 999.95K is rather 1M
+		if i+1 < len(units) && round(scaled, digits)*units[i].value >= units[i+1].value {
+			scaled, i = math.Abs(f)/units[i+1].value, i+1
+			if fractionDigits == defaultDigits {
+				digits = 1
+			}
+		}
+
+		text := p.printer.Sprint(number.Decimal(round(scaled, digits), number.MaxFractionDigits(digits))) +
+			units[i].suffix
+		if f < 0 {
+			return p.minus + text
+		}
+
+		return text
+	}
+
+	digits := fractionDigits
+	if digits == defaultDigits {
+		digits = 0
+		if math.Abs(f) < 10 {
+			digits = 1
+		}
+	}
+
+	return p.printer.Sprint(number.Decimal(f, number.MaxFractionDigits(digits)))
+}
+
+// round rounds half away from zero to the given fraction digits
+func round(f float64, digits int) float64 {
+	pow := math.Pow10(digits)
+	return math.Round(f*pow) / pow
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLocalizedNumbers(t *testing.T) {
+	tests := []struct {
+		locale string
+		text   string
+		args   []interface{}
+		want   string
+	}{
+		{"en", "%d files", []interface{}{1234567}, "1,234,567 files"},
+		{"de", "%d Dateien", []interface{}{1234567}, "1.234.567 Dateien"},
+		{"fr", "%d fichiers", []interface{}{1234567}, "1\u00a0234\u00a0567 fichiers"},
+		{"de-CH", "%d Dateien", []interface{}{1234567}, "1’234’567 Dateien"},
+		{"ar", "%d", []interface{}{1234}, "١٬٢٣٤"},
+		{"ar", "%d", []interface{}{12}, "١٢"},
+		{"de", "%.2f km", []interface{}{1234.5}, "1.234,50 km"},
+		{"de", "%v", []interface{}{0.5}, "0.5"},
+		{"de", "%v %d", []interface{}{2020, 2020}, "2020 2.020"},
+		{"de", "%6d|%v", []interface{}{12345, 2020}, "12.345|2020"},
+		{"und", "code %v %d %.1f", []interface{}{12345, 2020, 1234.5}, "code 12345 2020 1234.5"},
+		{"und", "%s", []interface{}{Currency{Amount: 1234.5, Code: "EUR"}}, "€1,234.50"},
+		{"de", "%-12v|", []interface{}{Currency{Amount: 3, Code: "EUR"}}, "3,00\u00a0€      |"},
+		{"en", "%.0v", []interface{}{Currency{Amount: 1234.4, Code: "EUR"}}, "€1,234"},
+		{"en", "%8.1v|", []interface{}{Percent(0.256)}, "   25.6%|"},
+		{"en", "%.2v", []interface{}{Compact(1234)}, "1.23K"},
+		{"de", "%.1v", []interface{}{NewDecimal(1234.56, 2)}, "1.234,6"},
+		{"de", "%[2]s %[1]d", []interface{}{1000, "x"}, "x 1.000"},
+		{"en", "%s", []interface{}{Currency{Amount: 1234.5, Code: "EUR"}}, "€1,234.50"},
+		{"de", "%s", []interface{}{Currency{Amount: 1234.5, Code: "EUR"}}, "1.234,50\u00a0€"},
+		{"de-CH", "%s", []interface{}{Currency{Amount: 1234.5, Code: "CHF"}}, "CHF\u00a01’234.50"},
+		{"nl", "%s", []interface{}{Currency{Amount: 3, Code: "EUR"}}, "€\u00a03,00"},
+		{"en", "%s", []interface{}{Currency{Amount: -1234, Code: "JPY"}}, "-¥1,234"},
+		{"en", "%s", []interface{}{Currency{Amount: 5, Code: "invalid"}}, "5.00 invalid"},
+		{"en", "%s", []interface{}{Percent(0.256)}, "26%"},
+		{"de", "%s", []interface{}{Percent(0.256)}, "26\u00a0%"},
+		{"en", "%s", []interface{}{Compact(1234)}, "1.2K"},
+		{"en", "%s", []interface{}{Compact(12345)}, "12K"},
+		{"en", "%s", []interface{}{Compact(999999)}, "1M"},
+		{"en", "%s", []interface{}{Compact(-2500000000)}, "-2.5B"},
+		{"en", "%s", []interface{}{Compact(999)}, "999"},
+		{"de", "%s", []interface{}{Compact(1234)}, "1.234"},
+		{"de", "%s", []interface{}{Compact(3400000)}, "3,4\u00a0Mio."},
+		{"ja", "%s", []interface{}{Compact(1234567)}, "123万"},
+		{"pt", "%s", []interface{}{Compact(1234567)}, "1.234.567"},
+		{"de", "%v", []interface{}{NewDecimal(1234.5, 2)}, "1.234,50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s: %s %v", tt.locale, tt.text, tt.args), func(t *testing.T) {
+			str, err := NewText(tt.locale, "id", tt.text).Text(tt.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+
+	// without a message locale, the placeholders still work with the fmt package
+	if str := fmt.Sprint(Currency{Amount: 3, Code: "EUR"}); str != "€3.00" {
+		t.Fatalf("expected '€3.00' but got '%s'", str)
+	}
+}
+
+func TestLocalizedMessageText(t *testing.T) {
+	const pattern = "{n, number}|{n, number, integer}|{n, number, ::percent}|{n, number, currency}|" +
+		"{n, number, currency/EUR}|{n, number, compact-short}|{c, plural, one {# Datei} other {# Dateien}}"
+
+	tests := []struct {
+		locale string
+		args   Args
+		want   string
+	}{
+		{"en-US", Args{"n": 12345.678, "c": 1500}, "12,345.678|12,346|1,234,568%|$12,345.68|€12,345.68|12K|1,500 Dateien"},
+		{"de-DE", Args{"n": 12345.678, "c": 1}, "12.345,678|12.346|1.234.568\u00a0%|12.345,68\u00a0€|12.345,68\u00a0€|" +
+			"12.346|1 Datei"},
+		{"de-DE", Args{"n": 0.5, "c": NewDecimal(1.5, 1)}, "0,5|0|50\u00a0%|0,50\u00a0€|0,50\u00a0€|0,5|1,5 Dateien"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			str, err := MustMessageText(tt.locale, "id", pattern).Text(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str != tt.want {
+				t.Fatalf("expected '%s' but got '%s'", tt.want, str)
+			}
+		})
+	}
+}
+
+func TestLocalizedResources(t *testing.T) {
+	setup()
+
+	ImportValue(NewText("de", "size", "%d Bytes"))
+	ImportValue(NewQuantityText("de", "files").One("%d Datei").Other("%d Dateien"))
+
+	res := From("de")
+
+	if str, _ := res.Text("size", 1048576); str != "1.048.576 Bytes" {
+		t.Fatalf("expected '1.048.576 Bytes' but got '%s'", str)
+	}
+
+	if str, _ := res.QuantityText("files", 2000, 2000); str != "2.000 Dateien" {
+		t.Fatalf("expected '2.000 Dateien' but got '%s'", str)
+	}
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = res.AppendText(buf[:0], "size", 512)
+	})
+
+	if allocs != 0 {
+		t.Fatalf("expected no allocations for small numbers but got %v", allocs)
+	}
+}
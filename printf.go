@@ -15,14 +15,16 @@
 package i18n
 
 import (
-	"regexp"
 	"sort"
 	"strconv"
-	"strings"
+	"unicode/utf8"
 )
 
-// matcher is a monster regex to find go supported printf declarations, which uses %[<num>]<verb>
-var formatMatcher = regexp.MustCompile(`(?:\x25\x25)|(\x25(?:(?:[[1-9]\d*)]|\((?:[^\)]+)\))?(?:\+)?(?:0|'[^$])?(?:-)?(?:\d+)?(?:\.(?:\d+))?(?:[b-fiosuxX]))`)
+// printfFlags are the flags of the fmt package
+const printfFlags = "#0+- "
+
+// maxPrintfNum is the limit of fmt for widths, precisions and argument indices
+const maxPrintfNum = 1e6
 
 // PrintFormatSpecifier represents a part of a printf string, like %s, %[5]d or %10.10s.
 type PrintfFormatSpecifier struct {
@@ -31,6 +33,15 @@ type PrintfFormatSpecifier struct {
 	End      int    // End is the end index in src where this specifier is located
 	Index    int    // Index is the argument position. Either this is the natural order or derived by the indexed position.
 	PosIndex int    // PosIndex is the positional index, which is the order as parsed
+
+	Arg          int    // Arg is the zero based index of the formatted argument, as fmt consumes it
+	Flags        string // Flags contains the declared flags out of "#0+- " in their order
+	Width        int    // Width is the declared width or -1
+	WidthArg     int    // WidthArg is the zero based index of the argument of a * width or -1
+	Precision    int    // Precision is the declared precision or -1. A dot without digits means 0.
+	PrecisionArg int    // PrecisionArg is the zero based index of the argument of a * precision or -1
+	Reordered    bool   // Reordered is true, if an explicit argument index like [2] has been declared
+	BadIndex     bool   // BadIndex is true, if fmt rejects the argument index, like %[0]d, and consumes no argument
 }
 
 // String returns the entire format specifier
@@ -43,52 +54,219 @@ func (f *PrintfFormatSpecifier) Verb() byte {
 	return f.Src[f.End-1]
 }
 
-// index parses tries to parse the from String(). Returns -1 if not
-func (f *PrintfFormatSpecifier) index() int {
-	str := f.String()
-	b := strings.Index(str, "[")
-	e := strings.Index(str, "]")
-	if e > b {
-		i, err := strconv.ParseInt(str[b+1:e], 10, 32)
-		if err != nil {
-			return -1
-		}
-		return int(i)
+// VerbRune returns the verb, which may also be a multi byte rune.
+func (f *PrintfFormatSpecifier) VerbRune() rune {
+	r, _ := utf8.DecodeLastRuneInString(f.String())
+	return r
+}
+
+// Plain returns true, if the directive has neither flags, width nor precision, like %s or %[2]d.
+func (f *PrintfFormatSpecifier) Plain() bool {
+	return f.Flags == "" && f.Width < 0 && f.WidthArg < 0 && f.Precision < 0 && f.PrecisionArg < 0
+}
+
+// Unindexed returns the directive without its argument indices, like %5.2f for %[2]5.2f. Any * width or precision
+// is kept. The result is only equivalent for letter verbs, because e.g. %[1]- would become the incomplete %-.
+func (f *PrintfFormatSpecifier) Unindexed() string {
+	buf := make([]byte, 0, f.End-f.Pos)
+	buf = append(buf, '%')
+	buf = append(buf, f.Flags...)
+
+	switch {
+	case f.WidthArg >= 0:
+		buf = append(buf, '*')
+	case f.Width >= 0:
+		buf = strconv.AppendInt(buf, int64(f.Width), 10)
+	}
+
+	switch {
+	case f.PrecisionArg >= 0:
+		buf = append(buf, '.', '*')
+	case f.Precision >= 0:
+		buf = append(buf, '.')
+		buf = strconv.AppendInt(buf, int64(f.Precision), 10)
 	}
-	return -1
+
+	return string(buf) + string(f.VerbRune())
 }
 
-// ParsePrintf returns all found format specifiers and returns them in a sorted order by index position
+// ParsePrintf returns all found format specifiers and returns them in a sorted order by index position. The
+// scanner follows the grammar of the fmt package, so %v, %q, %#x, %*d or %[2]*[1]d are found as well, but a %%
+// is not a specifier. Index enumerates the specifiers in the order of the arguments, which they consume.
 func ParsePrintf(str string) []PrintfFormatSpecifier {
 	var specs []PrintfFormatSpecifier
-	indices := formatMatcher.FindAllStringIndex(str, -1)
-	idx := 0
-	for _, pos := range indices {
-		spec := PrintfFormatSpecifier{
-			Src:      str,
-			Pos:      pos[0],
-			End:      pos[1],
-			Index:    idx,
-			PosIndex: idx,
-		}
-		overloadedIndex := spec.index()
-		if overloadedIndex > -1 {
-			spec.Index = overloadedIndex
+
+	argNum := 0
+
+	for i := 0; i < len(str); {
+		if str[i] != '%' {
+			i++
+			continue
 		}
-		if spec.String() == "%%" {
+
+		var spec PrintfFormatSpecifier
+
+		var ok bool
+
+		spec, argNum, ok = scanPrintf(str, i, argNum)
+		i = spec.End
+
+		if !ok {
 			continue
 		}
+
+		spec.PosIndex = len(specs)
 		specs = append(specs, spec)
-		idx++
 	}
-	sort.Sort(pfsSortByIndex(specs))
+
+	sort.Stable(pfsSortByIndex(specs))
 	// enumerate cleanly
 	for i := range specs {
 		specs[i].Index = i
 	}
+
 	return specs
 }
 
+// scanPrintf scans the directive at pos like fmt does and returns the next argument number. A directive
+// without verb or with the % verb consumes no argument and is not ok.
+func scanPrintf(str string, pos int, argNum int) (spec PrintfFormatSpecifier, nextArg int, ok bool) {
+	spec = PrintfFormatSpecifier{Src: str, Pos: pos, Width: -1, WidthArg: -1, Precision: -1, PrecisionArg: -1}
+	end := len(str)
+	i := pos + 1
+
+	for i < end && isPrintfFlag(str[i]) {
+		i++
+	}
+
+	spec.Flags = str[pos+1 : i]
+
+	argNum, i, afterIndex := spec.argNumber(argNum, i)
+
+	if i < end && str[i] == '*' {
+		i++
+		spec.WidthArg = argNum
+		argNum++
+		afterIndex = false
+	} else {
+		var isNum bool
+		if spec.Width, isNum, i = parsePrintfNum(str, i, end); !isNum {
+			spec.Width = -1
+		} else if afterIndex {
+			// like %[3]2d
+			spec.BadIndex = true
+		}
+	}
+
+	if i+1 < end && str[i] == '.' {
+		i++
+
+		if afterIndex {
+			// like %[3].2d
+			spec.BadIndex = true
+		}
+
+		argNum, i, afterIndex = spec.argNumber(argNum, i)
+
+		if i < end && str[i] == '*' {
+			i++
+			spec.PrecisionArg = argNum
+			argNum++
+			afterIndex = false
+		} else {
+			spec.Precision, _, i = parsePrintfNum(str, i, end)
+		}
+	}
+
+	if !afterIndex {
+		argNum, i, _ = spec.argNumber(argNum, i)
+	}
+
+	if i >= end {
+		spec.End = end
+		return spec, argNum, false
+	}
+
+	_, size := utf8.DecodeRuneInString(str[i:])
+	spec.End = i + size
+
+	if str[i] == '%' {
+		return spec, argNum, false
+	}
+
+	spec.Arg = argNum
+	if !spec.BadIndex {
+		argNum++
+	}
+
+	return spec, argNum, true
+}
+
+// argNumber parses an optional argument index like [2] at i, like fmt does
+func (f *PrintfFormatSpecifier) argNumber(argNum int, i int) (newArgNum int, newi int, found bool) {
+	str := f.Src
+	if i >= len(str) || str[i] != '[' {
+		return argNum, i, false
+	}
+
+	f.Reordered = true
+
+	// there must be at least 3 bytes: [n]
+	if len(str)-i < 3 {
+		f.BadIndex = true
+		return argNum, i + 1, false
+	}
+
+	for j := i + 1; j < len(str); j++ {
+		if str[j] == ']' {
+			num, isNum, newj := parsePrintfNum(str, i+1, j)
+			if !isNum || newj != j || num < 1 {
+				f.BadIndex = true
+				return argNum, j + 1, isNum && newj == j
+			}
+
+			return num - 1, j + 1, true
+		}
+	}
+
+	f.BadIndex = true
+
+	return argNum, i + 1, false
+}
+
+// parsePrintfNum parses a decimal number like fmt does. An overflow consumes everything up to end.
+func parsePrintfNum(str string, start, end int) (num int, isNum bool, newi int) {
+	if start >= end {
+		return 0, false, end
+	}
+
+	for newi = start; newi < end && '0' <= str[newi] && str[newi] <= '9'; newi++ {
+		if num > maxPrintfNum {
+			return 0, false, end
+		}
+
+		num = num*10 + int(str[newi]-'0')
+		isNum = true
+	}
+
+	return num, isNum, newi
+}
+
+// isLetter returns true for the ascii letters, which are the only meaningful verbs
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isPrintfFlag(c byte) bool {
+	for i := 0; i < len(printfFlags); i++ {
+		if printfFlags[i] == c {
+			return true
+		}
+	}
+
+	return false
+}
+
 type pfsSortByIndex []PrintfFormatSpecifier
 
 func (p pfsSortByIndex) Len() int {
@@ -96,7 +274,7 @@ func (p pfsSortByIndex) Len() int {
 }
 
 func (p pfsSortByIndex) Less(i, j int) bool {
-	return p[i].Index < p[j].Index
+	return p[i].Arg < p[j].Arg
 }
 
 func (p pfsSortByIndex) Swap(i, j int) {
@@ -0,0 +1,70 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// FuzzParsePrintf checks the scanner against fmt: a compiled message must render exactly like fmt.Sprintf and
+// each specifier must be a complete directive. Run it with:
+//
+//	go test -run=^$ -fuzz=FuzzParsePrintf .
+func FuzzParsePrintf(f *testing.F) {
+	for _, seed := range []string{
+		"hello %s", "%d files", "%5.2f km", "100%% of %s", "%[2]s owns %[1]d cats", "%[2]s %s", "%*d", "%-*.*f",
+		"%[3]*.[2]*[1]f", "%[0]d", "%[2]5d", "%#x %+q % d", "%v%t", "100%", "%-5%", "%.f", "%[1]%", "%ä",
+		"%[x]d", "%[", "%[99999999999]d",
+	} {
+		f.Add(seed)
+	}
+
+	args := []interface{}{"str", 42, 3.5, true, int64(-7)}
+
+	f.Fuzz(func(t *testing.T, str string) {
+		if len(str) > 64 || strings.Contains(str, "99999") {
+			// avoid huge paddings
+			t.Skip()
+		}
+
+		msg := compileMessage(str)
+
+		for n := 0; n <= len(args); n++ {
+			expected := fmt.Sprintf(str, args[:n]...)
+			if got := msg.String(args[:n]...); got != expected {
+				t.Fatalf("'%s' with %d args: expected '%s' but got '%s'", str, n, expected, got)
+			}
+		}
+
+		for _, spec := range ParsePrintf(str) {
+			if str[spec.Pos] != '%' || spec.End <= spec.Pos+1 {
+				t.Fatalf("'%s': invalid specifier %+v", str, spec)
+			}
+
+			if !isLetter(spec.Verb()) {
+				continue
+			}
+
+			if out := fmt.Sprintf(spec.Unindexed(), 1, 2, 3); strings.Contains(out, "NOVERB") {
+				t.Fatalf("'%s': incomplete specifier '%s'", str, spec.String())
+			}
+		}
+	})
+}
@@ -57,7 +57,14 @@ func TestParsePrintf(t *testing.T) {
 		{"right-justification with spaces", "[%10s]", "%10s"},
 		{"left-justification with spaces", "[%-10s]", "%-10s"},
 		{"zero-padding works on strings too", "[%010s]", "%010s"},
-		{"use the custom padding character '#'", "[%'#10s]", "%'#10s"},
+		{"alternate format with width", "[%#10x]", "%#10x"},
+		{"default format", "[%v]", "%v"},
+		{"quoted string", "%%q = %q", "%q"},
+		{"boolean", "%t", "%t"},
+		{"star width", "[%*d]", "%*d"},
+		{"indexed star width and precision", "[%[3]*.[2]*[1]f]", "%[3]*.[2]*[1]f"},
+		{"space flag", "100% sure", "% s"},
+		{"multi byte verb", "%ä", "%ä"},
 		{"left-justification but with a cutoff of 10 characters", "[%10.10s]", "%10.10s"},
 	}
 	for _, tt := range tests {
@@ -68,3 +75,52 @@ func TestParsePrintf(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePrintfFields(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want PrintfFormatSpecifier
+	}{
+		{"plain", "%s", PrintfFormatSpecifier{Arg: 0, Width: -1, WidthArg: -1, Precision: -1, PrecisionArg: -1}},
+		{"flags", "%-+#0 d", PrintfFormatSpecifier{Flags: "-+#0 ", Width: -1, WidthArg: -1, Precision: -1,
+			PrecisionArg: -1}},
+		{"width and precision", "%10.3f", PrintfFormatSpecifier{Width: 10, WidthArg: -1, Precision: 3,
+			PrecisionArg: -1}},
+		{"empty precision", "%.f", PrintfFormatSpecifier{Width: -1, WidthArg: -1, Precision: 0, PrecisionArg: -1}},
+		{"star width", "%*.*f", PrintfFormatSpecifier{Arg: 2, Width: -1, WidthArg: 0, Precision: -1,
+			PrecisionArg: 1}},
+		{"indexed", "%[2]d", PrintfFormatSpecifier{Arg: 1, Width: -1, WidthArg: -1, Precision: -1,
+			PrecisionArg: -1, Reordered: true}},
+		{"indexed star", "%[3]*.[2]*[1]f", PrintfFormatSpecifier{Arg: 0, Width: -1, WidthArg: 2, Precision: -1,
+			PrecisionArg: 1, Reordered: true}},
+		{"width after index", "%[2]5d", PrintfFormatSpecifier{Arg: 1, Width: 5, WidthArg: -1, Precision: -1,
+			PrecisionArg: -1, Reordered: true, BadIndex: true}},
+		{"zero index", "%[0]d", PrintfFormatSpecifier{Arg: 0, Width: -1, WidthArg: -1, Precision: -1,
+			PrecisionArg: -1, Reordered: true, BadIndex: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs := ParsePrintf(tt.str)
+			if len(specs) != 1 {
+				t.Fatalf("expected one specifier but got %d", len(specs))
+			}
+
+			got := specs[0]
+			tt.want.Src, tt.want.End = tt.str, len(tt.str)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected\n%+v\nbut got\n%+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParsePrintfSkips(t *testing.T) {
+	for _, str := range []string{"", "100%%", "%-5%", "no verb %", "%[1]%", "overflow %99999999999d"} {
+		if specs := ParsePrintf(str); len(specs) != 0 {
+			t.Fatalf("expected no specifiers in '%s' but got %v", str, specs)
+		}
+	}
+}
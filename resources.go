@@ -19,26 +19,64 @@ import (
 	"golang.org/x/text/language"
 	"sort"
 	"sync"
+	"sync/atomic"
 )
 
-// Resources is a type for accessing an applications text resources. It is safe to use concurrently.
+// Resources is a type for accessing an applications text resources. It is safe to use concurrently. A missing key
+// is looked up in the fallback chain of the bundle, if the resources belong to one. Reads are lock-free, because
+// they always work on an immutable snapshot of the values. Each modification publishes a new snapshot.
 type Resources struct {
-	tag    language.Tag
-	values map[string]Value
-	mutex  sync.RWMutex
+	tag       language.Tag
+	published atomic.Value // published contains the immutable map[string]Value snapshot
+	mutex     sync.Mutex   // mutex serializes the writers
+	values    map[string]Value
+	parent    *localizations
 }
 
 func newResources(tag language.Tag) *Resources {
-	return &Resources{
-		tag:    tag,
-		values: make(map[string]Value),
+	res := &Resources{tag: tag}
+	res.published.Store(make(map[string]Value))
+
+	return res
+}
+
+// load returns the current immutable snapshot of the values
+func (l *Resources) load() map[string]Value {
+	return l.published.Load().(map[string]Value)
+}
+
+// lock starts a modification. The values field contains a private copy of the current snapshot until unlock.
+func (l *Resources) lock() {
+	l.mutex.Lock()
+
+	current := l.load()
+	l.values = make(map[string]Value, len(current))
+
+	for k, v := range current {
+		l.values[k] = v
 	}
 }
 
+// unlock publishes the modified values as the new snapshot and releases the lock. New values are compiled once
+// here for the language of the resources, so that formatting does not need to parse the templates again.
+func (l *Resources) unlock() {
+	for k, v := range l.values {
+		l.values[k] = v.updateTag(l.tag).compile()
+	}
+
+	l.published.Store(l.values)
+	l.values = nil
+	l.mutex.Unlock()
+}
+
 // Keys returns all available text resource keys
 func (l *Resources) Keys() []string {
-	tmp := make([]string, 0, len(l.values))
-	for k, _ := range l.values {
+	return sortedKeys(l.load())
+}
+
+func sortedKeys(values map[string]Value) []string {
+	tmp := make([]string, 0, len(values))
+	for k := range values {
 		tmp = append(tmp, k)
 	}
 
@@ -46,37 +84,64 @@ func (l *Resources) Keys() []string {
 	return tmp
 }
 
-// Values returns the value for the key or nil
+// Value returns the value for the key or nil. In contrast to Lookup, the fallback chain is not searched.
 func (l *Resources) Value(key string) Value {
-	if v, ok := l.values[key]; ok {
+	if v, ok := l.load()[key]; ok {
 		return v
 	}
 
 	return nil
 }
 
+// Locale returns the CLDR language tag of these resources
+func (l *Resources) Locale() string {
+	return l.tag.String()
+}
+
+// Lookup returns the value and the locale, which actually contains the value. If the value is missing, the fallback
+// chain is searched. Returns ErrTextNotFound, if no locale contains the value.
+func (l *Resources) Lookup(id string) (Value, string, error) {
+	value := l.load()[id]
+
+	if value != nil {
+		return value, l.tag.String(), nil
+	}
+
+	if l.parent != nil {
+		for _, res := range l.parent.Fallbacks(l.tag) {
+			value = res.load()[id]
+
+			if value != nil {
+				return value, res.tag.String(), nil
+			}
+		}
+	}
+
+	return nil, "", ErrTextNotFound
+}
+
 // TextArray returns a defensive copy of the according string array
 // or ErrTextNotFound.
 func (l *Resources) TextArray(id string) ([]string, error) {
-	l.mutex.RLock()
-	defer l.mutex.RUnlock()
-
-	value := l.values[id]
-	if value == nil {
-		return nil, ErrTextNotFound
+	value, _, err := l.Lookup(id)
+	if err != nil {
+		return nil, err
 	}
 
 	return value.TextArray()
 }
 
+// List joins the items by the CLDR list patterns of the resources language, like "a, b, and c" in english or
+// "a, b und c" in german.
+func (l *Resources) List(items []string, style ListStyle) string {
+	return printerOf(l.tag).list(items, style)
+}
+
 // Text returns a translated string or ErrTextNotFound
 func (l *Resources) Text(id string, args ...interface{}) (string, error) {
-	l.mutex.RLock()
-	defer l.mutex.RUnlock()
-
-	value := l.values[id]
-	if value == nil {
-		return "", ErrTextNotFound
+	value, _, err := l.Lookup(id)
+	if err != nil {
+		return "", err
 	}
 
 	return value.Text(args...)
@@ -84,13 +149,75 @@ func (l *Resources) Text(id string, args ...interface{}) (string, error) {
 
 // QuantityText returns a translated and grammatically correct pluralization string or ErrTextNotFound
 func (l *Resources) QuantityText(id string, quantity int, args ...interface{}) (string, error) {
-	l.mutex.RLock()
-	defer l.mutex.RUnlock()
-
-	value := l.values[id]
-	if value == nil {
-		return "", ErrTextNotFound
+	value, _, err := l.Lookup(id)
+	if err != nil {
+		return "", err
 	}
 
 	return value.QuantityText(quantity, args...)
 }
+
+// QuantityTextDecimal returns a translated and grammatically correct pluralization string for a decimal quantity or
+// ErrTextNotFound. Use it, if the quantity is shown with fraction digits, like "1.5 hours" or "1.0 km", because
+// the visible fraction digits select different plurals in many languages.
+func (l *Resources) QuantityTextDecimal(id string, quantity Decimal, args ...interface{}) (string, error) {
+	value, _, err := l.Lookup(id)
+	if err != nil {
+		return "", err
+	}
+
+	return value.QuantityTextDecimal(quantity, args...)
+}
+
+// SelectText returns the translated case for the selector, like a grammatical gender, or ErrTextNotFound. An unknown
+// selector returns the other case.
+func (l *Resources) SelectText(id string, selector string, args ...interface{}) (string, error) {
+	value, _, err := l.Lookup(id)
+	if err != nil {
+		return "", err
+	}
+
+	return value.SelectText(selector, args...)
+}
+
+// OrdinalText returns a translated and grammatically correct ordinal string, like "your 2nd order", or
+// ErrTextNotFound. It is equivalent to QuantityText, because each plural knows its rules, but reads better.
+func (l *Resources) OrdinalText(id string, ordinal int, args ...interface{}) (string, error) {
+	return l.QuantityText(id, ordinal, args...)
+}
+
+// AppendText appends a translated string to dst or returns ErrTextNotFound. The message has been compiled at import
+// time, so that plain directives for strings, integers and floats are formatted without any allocation. Only
+// directives with flags, width or precision and unusual argument types are delegated to the fmt package.
+func (l *Resources) AppendText(dst []byte, id string, args ...interface{}) ([]byte, error) {
+	value, _, err := l.Lookup(id)
+	if err != nil {
+		return dst, err
+	}
+
+	if m, ok := value.(messageFormatValue); ok {
+		return m.append(dst, m.named(args))
+	}
+
+	return value.message().append(dst, args), nil
+}
+
+// AppendQuantityText appends a translated and grammatically correct pluralization string to dst or returns
+// ErrTextNotFound.
+func (l *Resources) AppendQuantityText(dst []byte, id string, quantity int, args ...interface{}) ([]byte, error) {
+	value, _, err := l.Lookup(id)
+	if err != nil {
+		return dst, err
+	}
+
+	if m, ok := value.(messageFormatValue); ok {
+		return m.append(dst, m.withQuantity(quantity, args))
+	}
+
+	msg, formatArgs := value.quantityMessage(quantity, 0, 0, 0, 0)
+	if !formatArgs {
+		return msg.append(dst, nil), nil
+	}
+
+	return msg.append(dst, args), nil
+}
@@ -0,0 +1,313 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"fmt"
+	"github.com/golangee/i18n/android"
+	"github.com/iancoleman/strcase"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// keyMethods are the Resources methods, which accept a key, and the index of the key argument
+// nolint: gochecknoglobals
+var keyMethods = map[string]int{
+	"Text":                1,
+	"QuantityText":        1,
+	"QuantityTextDecimal": 1,
+	"OrdinalText":         1,
+	"SelectText":          1,
+	"TextArray":           1,
+	"AppendText":          2,
+	"Lookup":              1,
+	"Value":               1,
+}
+
+// templateExtensions are the file extensions of text/template and html/template files
+// nolint: gochecknoglobals
+var templateExtensions = map[string]bool{
+	".tmpl":   true,
+	".tpl":    true,
+	".gotmpl": true,
+	".gohtml": true,
+	".html":   true,
+}
+
+// nolint: gochecknoglobals
+var (
+	templateAction = regexp.MustCompile(`{{(?s:.*?)}}`)
+	identifier     = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// UnusedKeys are the keys of the strings*.xml files of a package, which are used neither by a generated accessor
+// nor by a literal key nor by a template.
+type UnusedKeys struct {
+	Package string   // Package is the name of the package
+	Dir     string   // Dir is the directory of the package
+	Files   []string // Files are the strings*.xml files of the package
+	Keys    []string // Keys are the unused keys in sorted order
+}
+
+// Remove deletes the unused keys from the strings*.xml files of the package. Everything else is kept as is, see
+// also android.Remove.
+func (u UnusedKeys) Remove() error {
+	names := make(map[string]bool, len(u.Keys))
+	for _, key := range u.Keys {
+		names[key] = true
+	}
+
+	for _, file := range u.Files {
+		stat, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("unable to stat %s: %w", file, err)
+		}
+
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", file, err)
+		}
+
+		dst, err := android.Remove(src, names)
+		if err != nil {
+			return fmt.Errorf("unable to remove keys from %s: %w", file, err)
+		}
+
+		if err := ioutil.WriteFile(file, dst, stat.Mode()); err != nil {
+			return fmt.Errorf("unable to write %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// FindUnusedKeys cross-references the keys of all strings*.xml files below dir, which are found just like the
+// generator finds them, with the go files and templates below dir. A key is used, if
+//   - any selector has the name of its generated accessor, like res.HelloX or the FuncMap entry r.HelloX or
+//   - any call of a Resources method, like Text or QuantityText, has it as a literal or constant key or
+//   - any template action contains the name of its accessor, like {{HelloX .Name}}, also in go string literals.
+//
+// Generated go files are ignored, because they contain the accessors of all keys. The result only contains
+// packages with unused keys.
+func FindUnusedKeys(dir string) ([]UnusedKeys, error) {
+	t, err := ScanTranslations(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Unused()
+}
+
+// Unused is like FindUnusedKeys for the scanned module
+func (t *Translations) Unused() ([]UnusedKeys, error) {
+	usages := newKeyUsages()
+	if err := usages.scan(t.opts.Dir); err != nil {
+		return nil, err
+	}
+
+	var res []UnusedKeys
+
+	for _, translation := range t.translations {
+		unused := UnusedKeys{Package: translation.pkg.Name, Dir: translation.pkg.Dir}
+		for _, file := range translation.files {
+			unused.Files = append(unused.Files, file.filename)
+		}
+
+		for _, value := range translation.collectValues() {
+			if !usages.used(value) {
+				unused.Keys = append(unused.Keys, value.ID())
+			}
+		}
+
+		if len(unused.Keys) > 0 {
+			res = append(res, unused)
+		}
+	}
+
+	return res, nil
+}
+
+// keyUsages collects the names of selectors, literal keys and identifiers in template actions
+type keyUsages struct {
+	selectors map[string]bool
+	keys      map[string]bool
+	templates map[string]bool
+}
+
+func newKeyUsages() *keyUsages {
+	return &keyUsages{
+		selectors: make(map[string]bool),
+		keys:      make(map[string]bool),
+		templates: make(map[string]bool),
+	}
+}
+
+// used checks the key and the names of the generated accessors
+func (u *keyUsages) used(value Value) bool {
+	if u.keys[value.ID()] {
+		return true
+	}
+
+	names := []string{strcase.ToCamel(value.ID())}
+	if p, ok := value.(pluralValue); ok && !p.ordinal {
+		names = append(names, names[0]+"Decimal")
+	}
+
+	for _, name := range names {
+		if u.selectors[name] || u.templates[name] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scan walks through all go and template files but ignores hidden, vendor and testdata directories
+func (u *keyUsages) scan(dir string) error {
+	fset := token.NewFileSet()
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := info.Name()
+		if info.IsDir() {
+			if path != dir && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		switch {
+		case strings.HasSuffix(name, ".go"):
+			file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+			if err != nil {
+				return fmt.Errorf("unable to parse %s: %w", path, err)
+			}
+
+			if !generated(file) {
+				u.inspect(file)
+			}
+		case templateExtensions[filepath.Ext(name)]:
+			buf, err := ioutil.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("unable to read %s: %w", path, err)
+			}
+
+			u.template(string(buf))
+		}
+
+		return nil
+	})
+}
+
+// inspect collects the selector names, the literal keys and templates in string literals
+func (u *keyUsages) inspect(file *ast.File) {
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.SelectorExpr:
+			u.selectors[n.Sel.Name] = true
+		case *ast.BasicLit:
+			if str, ok := stringLit(n); ok && strings.Contains(str, "{{") {
+				u.template(str)
+			}
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok {
+				break
+			}
+
+			if idx, ok := keyMethods[sel.Sel.Name]; ok && idx <= len(n.Args) {
+				if key, ok := constString(n.Args[idx-1]); ok {
+					u.keys[key] = true
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// template collects all identifiers within the actions of the template
+func (u *keyUsages) template(text string) {
+	for _, action := range templateAction.FindAllString(text, -1) {
+		for _, name := range identifier.FindAllString(action, -1) {
+			u.templates[name] = true
+		}
+	}
+}
+
+// generated detects the header comment of generated files, like the strings_gen.go files
+func generated(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			return false
+		}
+
+		for _, comment := range group.List {
+			if strings.HasPrefix(comment.Text, "// Code generated ") && strings.HasSuffix(comment.Text, " DO NOT EDIT.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// constString returns the value of a string literal or of an identifier, which refers to a string constant of the
+// same file
+func constString(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return stringLit(e)
+	case *ast.Ident:
+		if e.Obj == nil || e.Obj.Kind != ast.Con {
+			return "", false
+		}
+
+		spec, ok := e.Obj.Decl.(*ast.ValueSpec)
+		if !ok {
+			return "", false
+		}
+
+		for i, name := range spec.Names {
+			if name.Name == e.Name && i < len(spec.Values) {
+				return constString(spec.Values[i])
+			}
+		}
+	}
+
+	return "", false
+}
+
+func stringLit(lit *ast.BasicLit) (string, bool) {
+	if lit.Kind != token.STRING {
+		return "", false
+	}
+
+	str, err := strconv.Unquote(lit.Value)
+
+	return str, err == nil
+}
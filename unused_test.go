@@ -0,0 +1,125 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindUnusedKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "unused")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	files := map[string]string{
+		"strings.xml": `<resources>
+    <string name="by_accessor">accessor</string>
+    <string name="by_literal">literal %s</string>
+    <string name="by_const">constant</string>
+    <string name="by_template">template</string>
+    <string name="by_inline_template">inline template</string>
+    <string name="unused_text">unused</string>
+    <plurals name="x_cats">
+        <item quantity="one">%d cat</item>
+        <item quantity="other">%d cats</item>
+    </plurals>
+    <string-array name="unused_days">
+        <item>Monday</item>
+    </string-array>
+</resources>
+`,
+		"strings-de.xml": `<resources>
+    <string name="unused_text">unbenutzt</string>
+    <string name="by_accessor">Accessor</string>
+</resources>
+`,
+		"main.go": `package main
+
+import "github.com/golangee/i18n"
+
+const constKey = "by_const"
+
+const page = "<p>{{ByInlineTemplate}}</p>"
+
+func main() {
+	res := NewResources("en")
+	_ = res.ByAccessor()
+	_ = res.XCatsDecimal(i18n.Decimal{}, 3)
+
+	_, _ = i18n.From("en").Text("by_literal", "x")
+	_, _ = i18n.From("en").Text(constKey)
+}
+`,
+		"strings_gen.go": `// Code generated by go generate; DO NOT EDIT.
+package main
+
+func (r Resources) UnusedText() string {
+	str, _ := r.res.Text("unused_text")
+	return str
+}
+`,
+		"page.gohtml": `<h1>{{ .Title }}</h1>
+<p>{{ByTemplate}}</p>`,
+	}
+
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	unused, err := FindUnusedKeys(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(unused) != 1 || unused[0].Package != "main" || len(unused[0].Files) != 2 ||
+		!reflect.DeepEqual(unused[0].Keys, []string{"unused_days", "unused_text"}) {
+		t.Fatalf("unexpected unused keys %+v", unused)
+	}
+
+	if err := unused[0].Remove(); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := ioutil.ReadFile(filepath.Join(dir, "strings-de.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "<resources>\n    <string name=\"by_accessor\">Accessor</string>\n</resources>\n"; string(buf) != want {
+		t.Fatalf("expected\n%s\nbut got\n%s", want, string(buf))
+	}
+
+	unused, err = FindUnusedKeys(dir)
+	if err != nil || len(unused) != 0 {
+		t.Fatalf("expected no unused keys after the removal but got %+v: %v", unused, err)
+	}
+
+	buf, err = ioutil.ReadFile(filepath.Join(dir, "strings.xml"))
+	if err != nil || strings.Contains(string(buf), "unused") || !strings.Contains(string(buf), "by_template") {
+		t.Fatalf("unexpected strings.xml:\n%s", string(buf))
+	}
+}
@@ -17,6 +17,8 @@ package i18n
 import (
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -37,3 +39,56 @@ func guessLocaleFromFilename(str string) string {
 	}
 	return tmp
 }
+
+// splitArrayKey splits a key like "name[3]" into its name and index. Formats without arrays use such keys.
+func splitArrayKey(key string) (string, int, bool) {
+	if !strings.HasSuffix(key, "]") {
+		return "", 0, false
+	}
+
+	b := strings.LastIndex(key, "[")
+	if b <= 0 {
+		return "", 0, false
+	}
+
+	idx, err := strconv.Atoi(key[b+1 : len(key)-1])
+	if err != nil || idx < 0 {
+		return "", 0, false
+	}
+
+	return key[:b], idx, true
+}
+
+// arrayItems collects the indexed items of key based formats, see also splitArrayKey
+type arrayItems map[string]map[int]string
+
+func (a arrayItems) add(name string, idx int, text string) {
+	if a[name] == nil {
+		a[name] = make(map[int]string)
+	}
+
+	a[name][idx] = text
+}
+
+// importInto adds an arrayValue for each name, ordered by the item indices. The caller must hold the lock.
+func (a arrayItems) importInto(dst *Resources) {
+	for name, items := range a {
+		indices := make([]int, 0, len(items))
+		for i := range items {
+			indices = append(indices, i)
+		}
+
+		sort.Ints(indices)
+
+		tmp := make([]string, 0, len(indices))
+		for _, i := range indices {
+			tmp = append(tmp, items[i])
+		}
+
+		dst.values[name] = arrayValue{
+			Id:      name,
+			locale:  dst.tag.String(),
+			Strings: tmp,
+		}
+	}
+}
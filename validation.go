@@ -27,7 +27,8 @@ type ErrMissingValue struct {
 }
 
 func (e ErrMissingValue) Error() string {
-	return "the locale '" + e.Value.Locale() + "' has the extra value '" + e.Value.ID() + "' which is missing in '" + e.MissingInLocale + "'"
+	return located("the locale '"+e.Value.Locale()+"' has the extra value '"+e.Value.ID()+"' which is missing in '"+
+		e.MissingInLocale+"'", e.Value, nil)
 }
 
 // ErrTypeMismatch contains two Values of two different values which have different types, which is not allowed.
@@ -37,8 +38,21 @@ type ErrTypeMismatch struct {
 }
 
 func (e ErrTypeMismatch) Error() string {
-	return e.Value0.ID() + " is a " + reflect.TypeOf(e.Value0).String() + " in " + e.Value0.Locale() +
-		" but in " + e.Value1.Locale() + " a " + reflect.TypeOf(e.Value0).String()
+	return located(e.Value0.ID()+" is a "+kindOf(e.Value0)+" in "+e.Value0.Locale()+
+		" but in "+e.Value1.Locale()+" a "+kindOf(e.Value1), e.Value0, e.Value1)
+}
+
+// kindOf returns the type name of the value and distinguishes ordinal from cardinal plurals and lists from arrays
+func kindOf(v Value) string {
+	if p, ok := v.(pluralValue); ok && p.ordinal {
+		return reflect.TypeOf(v).String() + " (ordinal)"
+	}
+
+	if a, ok := v.(arrayValue); ok && a.list != "" {
+		return reflect.TypeOf(v).String() + " (" + a.list + " list)"
+	}
+
+	return reflect.TypeOf(v).String()
 }
 
 // ErrFormatSpecifierCountMismatch is used to indicates that the number printf formatting directives is different
@@ -51,8 +65,9 @@ type ErrFormatSpecifierCountMismatch struct {
 }
 
 func (e *ErrFormatSpecifierCountMismatch) Error() string {
-	return fmt.Sprintf("printf argument count mismatch: %s.%s has %d arguments but %s.%s has %d",
-		e.Value0.Locale(), e.Value0.ID(), len(e.Specs0), e.Value1.Locale(), e.Value1.ID(), len(e.Specs1))
+	return located(fmt.Sprintf("printf argument count mismatch: %s.%s has %d arguments but %s.%s has %d",
+		e.Value0.Locale(), e.Value0.ID(), len(e.Specs0), e.Value1.Locale(), e.Value1.ID(), len(e.Specs1)),
+		e.Value0, e.Value1)
 }
 
 // ErrArrayCountMismatch indicates that two arrays must have the same amount of entries
@@ -64,8 +79,8 @@ type ErrArrayCountMismatch struct {
 }
 
 func (e ErrArrayCountMismatch) Error() string {
-	return fmt.Sprintf("The array count in %s.%s is %d but %s.%s has %d",
-		e.Value0.Locale(), e.Value0.ID(), e.Count0, e.Value1.Locale(), e.Value1.ID(), e.Count1)
+	return located(fmt.Sprintf("The array count in %s.%s is %d but %s.%s has %d",
+		e.Value0.Locale(), e.Value0.ID(), e.Count0, e.Value1.Locale(), e.Value1.ID(), e.Count1), e.Value0, e.Value1)
 }
 
 // ErrUnexpectedAmountOfFormatSpecifiers indicates that a value has an unexpected amount of specifiers.
@@ -78,8 +93,8 @@ type ErrUnexpectedAmountOfFormatSpecifiers struct {
 }
 
 func (e *ErrUnexpectedAmountOfFormatSpecifiers) Error() string {
-	return fmt.Sprintf("The value %s.%s has %d format specifiers but expected are %d (%s)",
-		e.Value.Locale(), e.Value.ID(), e.Found, e.Expected, e.Text)
+	return located(fmt.Sprintf("The value %s.%s has %d format specifiers but expected are %d (%s)",
+		e.Value.Locale(), e.Value.ID(), e.Found, e.Expected, e.Text), e.Value, nil)
 }
 
 // ErrVerbConflict is returned, if two strings have different verb specifiers for the same position
@@ -91,20 +106,76 @@ type ErrVerbConflict struct {
 }
 
 func (e *ErrVerbConflict) Error() string {
-	return fmt.Sprintf("The value %s.%s has at index %d the verb '%s' but"+
+	return located(fmt.Sprintf("The value %s.%s has at index %d the verb '%s' but"+
 		" %s.%s has the verb '%s'",
 		e.Value0.Locale(), e.Value0.ID(), e.Verb0.Index, string(e.Verb0.Verb()),
-		e.Value1.Locale(), e.Value1.ID(), string(e.Verb1.Verb()))
+		e.Value1.Locale(), e.Value1.ID(), string(e.Verb1.Verb())), e.Value0, e.Value1)
 }
 
-// ErrOtherMissing indicates a missing "other" value for a plural. You may omit everything else but
+// ErrOtherMissing indicates a missing "other" value for a plural or select. You may omit everything else but
 // other is the fallback and must not be empty at least.
 type ErrOtherMissing struct {
 	Value Value
 }
 
 func (e ErrOtherMissing) Error() string {
-	return "the plural 'other' must not be empty of " + e.Value.Locale() + "." + e.Value.ID()
+	if _, ok := e.Value.(selectValue); ok {
+		return located("the select 'other' must not be empty of "+e.Value.Locale()+"."+e.Value.ID(), e.Value, nil)
+	}
+
+	return located("the plural 'other' must not be empty of "+e.Value.Locale()+"."+e.Value.ID(), e.Value, nil)
+}
+
+// ErrSelectCaseMismatch indicates that a select has a case, which is missing in the select of another locale.
+// The cases must be equal, because they are the typed selectors of the generated accessor.
+type ErrSelectCaseMismatch struct {
+	Value0   Value
+	Value1   Value
+	Selector string
+}
+
+func (e ErrSelectCaseMismatch) Error() string {
+	return located(fmt.Sprintf("the select %s.%s has the case '%s' which is missing in %s",
+		e.Value0.Locale(), e.Value0.ID(), e.Selector, e.Value1.Locale()), e.Value0, e.Value1)
+}
+
+// ErrArgumentMismatch indicates that an argument of an ICU message is missing or has a different type in another
+// locale. The types are the go types of the generated accessor, so {n, plural, ...} and {n, number, integer} are
+// both an int, but a {n, number} is a float64. A missing argument has an empty type.
+type ErrArgumentMismatch struct {
+	Value0 Value
+	Type0  string
+	Value1 Value
+	Type1  string
+	Name   string
+}
+
+func (e ErrArgumentMismatch) Error() string {
+	typeName := func(typ string) string {
+		if typ == "" {
+			return "missing"
+		}
+
+		return "a " + typ
+	}
+
+	return located(fmt.Sprintf("the argument '%s' of %s.%s is %s but in %s.%s it is %s", e.Name,
+		e.Value0.Locale(), e.Value0.ID(), typeName(e.Type0), e.Value1.Locale(), e.Value1.ID(), typeName(e.Type1)),
+		e.Value0, e.Value1)
+}
+
+// located prefixes the message with the origin of the value as file:line:col, so that editors can jump to it. The
+// origin of the other value, which is compared with, is appended. Unknown origins are omitted.
+func located(msg string, value, other Value) string {
+	if value != nil && value.Origin() != (Origin{}) {
+		msg = value.Origin().String() + ": " + msg
+	}
+
+	if other != nil && other.Origin() != (Origin{}) {
+		msg += " (see " + other.Origin().String() + ")"
+	}
+
+	return msg
 }
 
 // ErrList is a list of errors
@@ -125,6 +196,8 @@ func (e ErrList) Error() string {
 //  * each resources have the same keys
 //  * each resources have the same type
 //  * the order and type of verbs are equal
+//  * selects have the same cases
+//  * ICU messages have the same argument names and types
 func validate(resources []*Resources) error {
 	var errs []error
 	for i0, r0 := range resources {
@@ -133,11 +206,10 @@ func validate(resources []*Resources) error {
 			if r0 == r1 {
 				continue
 			}
-			r0.mutex.RLock()
-			r1.mutex.RLock()
+			values0, values1 := r0.load(), r1.load()
 
-			for k0, v0 := range r0.values {
-				v1, exists := r1.values[k0]
+			for k0, v0 := range values0 {
+				v1, exists := values1[k0]
 				if !exists {
 					errs = append(errs, ErrMissingValue{
 						MissingInLocale: r1.tag.String(),
@@ -157,6 +229,15 @@ func validate(resources []*Resources) error {
 							strErr = validatePrintf(t0.String, t1.String, -1)
 						case pluralValue:
 							t1 := v1.(pluralValue)
+							if t0.ordinal != t1.ordinal {
+								errs = append(errs, ErrTypeMismatch{
+									Value0: v0,
+									Value1: v1,
+								})
+
+								break
+							}
+
 							if len(t0.other) == 0 {
 								strErr = ErrOtherMissing{Value: v0}
 								break
@@ -166,19 +247,59 @@ func validate(resources []*Resources) error {
 								break
 							}
 
-						anyPlural:
-							for _, p0 := range t0.mapOf() {
-								for _, p1 := range t1.mapOf() {
-									strErr = validatePrintf(p0, p1, -1)
+							// locales have different categories and e.g. 'one' often omits the number, so only the
+							// fallback 'other' and the categories, which both locales define, must be consistent
+							forms1 := t1.mapOf()
+							for _, f := range t0.forms() {
+								if p1, ok := forms1[f.category]; ok {
+									strErr = validatePrintf(f.text, p1, -1)
 									if strErr != nil {
-										break anyPlural
+										break
 									}
 								}
 							}
 
+						case selectValue:
+							t1 := v1.(selectValue)
+							if caseErr := validateSelectCases(t0, t1); caseErr != nil {
+								errs = append(errs, caseErr)
+								break
+							}
+
+							if len(t0.other) == 0 {
+								strErr = ErrOtherMissing{Value: v0}
+								break
+							}
+							if len(t1.other) == 0 {
+								strErr = ErrOtherMissing{Value: v1}
+								break
+							}
+
+							// each case must be consistent with the same case and the other case of each locale
+							for _, selector := range append(t0.selectors(), other) {
+								strErr = validatePrintf(t0.get(selector), t1.get(selector), -1)
+								if strErr == nil {
+									strErr = validatePrintf(t0.get(selector), t1.other, -1)
+								}
+
+								if strErr != nil {
+									break
+								}
+							}
+
+						case messageFormatValue:
+							if argErr := validateArguments(t0, v1.(messageFormatValue)); argErr != nil {
+								errs = append(errs, argErr)
+							}
+
 						case arrayValue:
 							t1 := v1.(arrayValue)
-							if len(t0.Strings) != len(t1.Strings) {
+							if t0.list != t1.list {
+								errs = append(errs, ErrTypeMismatch{
+									Value0: v0,
+									Value1: v1,
+								})
+							} else if len(t0.Strings) != len(t1.Strings) {
 								errs = append(errs, ErrArrayCountMismatch{
 									Value0: v0,
 									Count0: len(t0.Strings),
@@ -213,9 +334,6 @@ func validate(resources []*Resources) error {
 
 				}
 			}
-
-			r0.mutex.RUnlock()
-			r1.mutex.RUnlock()
 		}
 	}
 	if len(errs) == 0 {
@@ -224,6 +342,44 @@ func validate(resources []*Resources) error {
 	return ErrList{errs}
 }
 
+// validateSelectCases returns an ErrSelectCaseMismatch for the first case, which is not declared by both values
+func validateSelectCases(v0, v1 selectValue) error {
+	for _, selector := range v0.selectors() {
+		if !v1.has(selector) {
+			return ErrSelectCaseMismatch{Value0: v0, Value1: v1, Selector: selector}
+		}
+	}
+
+	for _, selector := range v1.selectors() {
+		if !v0.has(selector) {
+			return ErrSelectCaseMismatch{Value0: v1, Value1: v0, Selector: selector}
+		}
+	}
+
+	return nil
+}
+
+// validateArguments returns an ErrArgumentMismatch for the first argument, whose type is not equal in both messages
+func validateArguments(v0, v1 messageFormatValue) error {
+	types0, types1 := make(map[string]string), make(map[string]string)
+	for _, arg := range v0.arguments() {
+		types0[arg.Name] = argumentType(arg)
+	}
+
+	for _, arg := range v1.arguments() {
+		types1[arg.Name] = argumentType(arg)
+	}
+
+	for _, arg := range append(v0.arguments(), v1.arguments()...) {
+		if types0[arg.Name] != types1[arg.Name] {
+			return ErrArgumentMismatch{Value0: v0, Type0: types0[arg.Name], Value1: v1, Type1: types1[arg.Name],
+				Name: arg.Name}
+		}
+	}
+
+	return nil
+}
+
 // validatePrintf validates str0 and str1 to be of equal golang printf format directives. If expected is not -1
 // an error is returned, if the amount of directives does not match the expected number.
 func validatePrintf(str0, str1 string, expected int) error {
@@ -20,7 +20,7 @@ import (
 	"github.com/dave/jennifer/jen"
 	"golang.org/x/text/feature/plural"
 	"golang.org/x/text/language"
-	"log"
+	"sort"
 )
 
 // A Value is a contract which is implemented by each kind of message Value, like simple, array or plural.
@@ -36,14 +36,56 @@ type Value interface {
 	// QuantityText formats the value with the given arguments
 	QuantityText(quantity int, args ...interface{}) (string, error)
 
+	// QuantityTextDecimal formats the value with the given arguments and selects the plural by a decimal quantity
+	QuantityTextDecimal(quantity Decimal, args ...interface{}) (string, error)
+
+	// SelectText formats the value with the given arguments and selects the case by the selector
+	SelectText(selector string, args ...interface{}) (string, error)
+
 	// Locale returns the CLDR language tag
 	Locale() string
+
+	// Note returns an optional description for translators, like the context in which the text is shown
+	Note() string
+
+	// Origin returns the position of the value in its source file, if it has been imported from a file
+	Origin() Origin
 	goEmitImportValue(group *jen.Group)
 	goEmitGetter() *jen.Statement
 	exampleText() string
 
 	// implementation detail
 	updateTag(tag language.Tag) Value
+
+	// compile returns the value with its precompiled messages
+	compile() Value
+
+	// message returns the compiled text
+	message() *message
+
+	// quantityMessage returns the compiled plural text and if the arguments shall be formatted at all
+	quantityMessage(i, v, w, f, t int) (msg *message, formatArgs bool)
+}
+
+// An Origin is the position of a value in its source file. The zero Origin is unknown, like for values, which are
+// created by NewText.
+type Origin struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String renders the origin as file:line:col, so that editors can jump to it. An unknown file is left out.
+func (o Origin) String() string {
+	if o.Line == 0 {
+		return o.File
+	}
+
+	if o.File == "" {
+		return fmt.Sprintf("%d:%d", o.Line, o.Column)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", o.File, o.Line, o.Column)
 }
 
 type PluralBuilder interface {
@@ -68,15 +110,54 @@ type pluralValue struct {
 	many   string
 	other  string
 	tag    language.Tag
+	note   string
+	origin Origin
+
+	// ordinal plurals use the CLDR ordinal rules, like 1st, 2nd or 3rd, instead of the cardinal ones
+	ordinal bool
+
+	// compiled messages are nil until compile, which happens at import time
+	compiled *pluralMessages
+}
+
+// pluralMessages are the compiled texts of a plural. An empty text has a nil message.
+type pluralMessages struct {
+	zero  *message
+	one   *message
+	two   *message
+	few   *message
+	many  *message
+	other *message
 }
 
 func NewQuantityText(locale string, id string) PluralBuilder {
 	return pluralValue{
 		locale: locale,
 		Id:     id,
+		tag:    language.Make(locale),
 	}
 }
 
+// NewOrdinalText returns a builder for a plural, which is resolved by the CLDR ordinal rules, like "your 2nd order"
+// or "ta 2e commande".
+func NewOrdinalText(locale string, id string) PluralBuilder {
+	return pluralValue{
+		locale:  locale,
+		Id:      id,
+		tag:     language.Make(locale),
+		ordinal: true,
+	}
+}
+
+// rules returns the ordinal or cardinal plural rules
+func (p pluralValue) rules() *plural.Rules {
+	if p.ordinal {
+		return plural.Ordinal
+	}
+
+	return plural.Cardinal
+}
+
 // mapOf returns a map of zero,one,two,few,many,other strings with their according value, if the value is not empty
 func (p pluralValue) mapOf() map[string]string {
 	m := make(map[string]string)
@@ -84,50 +165,129 @@ func (p pluralValue) mapOf() map[string]string {
 		m[zero] = p.zero
 	}
 	if len(p.one) > 0 {
-		m[one] = p.zero
+		m[one] = p.one
 	}
 	if len(p.two) > 0 {
-		m[two] = p.zero
+		m[two] = p.two
 	}
 	if len(p.few) > 0 {
-		m[few] = p.zero
+		m[few] = p.few
 	}
 	if len(p.many) > 0 {
-		m[many] = p.zero
+		m[many] = p.many
 	}
 	if len(p.other) > 0 {
-		m[other] = p.zero
+		m[other] = p.other
 	}
 	return m
 }
 
+// pluralForm is a text for a CLDR plural category
+type pluralForm struct {
+	category string
+	text     string
+}
+
+// forms returns the non-empty texts in the CLDR order zero, one, two, few, many and other
+func (p pluralValue) forms() []pluralForm {
+	var res []pluralForm
+	for _, f := range []pluralForm{{zero, p.zero}, {one, p.one}, {two, p.two}, {few, p.few}, {many, p.many},
+		{other, p.other}} {
+		if len(f.text) > 0 {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+// formName returns the CLDR category name of the given form, as used by the android quantity attribute
+func formName(form plural.Form) string {
+	switch form {
+	case plural.Zero:
+		return zero
+	case plural.One:
+		return one
+	case plural.Two:
+		return two
+	case plural.Few:
+		return few
+	case plural.Many:
+		return many
+	default:
+		return other
+	}
+}
+
+// set updates the text of the given CLDR category name, unknown names are treated as other
+func (p *pluralValue) set(category string, text string) {
+	switch category {
+	case zero:
+		p.zero = text
+	case one:
+		p.one = text
+	case two:
+		p.two = text
+	case few:
+		p.few = text
+	case many:
+		p.many = text
+	default:
+		p.other = text
+	}
+}
+
+// get returns the text of the given CLDR category name, unknown names are treated as other
+func (p pluralValue) get(category string) string {
+	switch category {
+	case zero:
+		return p.zero
+	case one:
+		return p.one
+	case two:
+		return p.two
+	case few:
+		return p.few
+	case many:
+		return p.many
+	default:
+		return p.other
+	}
+}
+
 func (p pluralValue) Zero(text string) PluralBuilder {
 	p.zero = text
+	p.compiled = nil
 	return p
 }
 
 func (p pluralValue) One(text string) PluralBuilder {
 	p.one = text
+	p.compiled = nil
 	return p
 }
 
 func (p pluralValue) Two(text string) PluralBuilder {
 	p.two = text
+	p.compiled = nil
 	return p
 }
 
 func (p pluralValue) Few(text string) PluralBuilder {
 	p.few = text
+	p.compiled = nil
 	return p
 }
 
 func (p pluralValue) Many(text string) PluralBuilder {
 	p.many = text
+	p.compiled = nil
 	return p
 }
 
 func (p pluralValue) Other(text string) PluralBuilder {
 	p.other = text
+	p.compiled = nil
 	return p
 }
 
@@ -135,11 +295,59 @@ func (p pluralValue) Locale() string {
 	return p.locale
 }
 
+func (p pluralValue) Note() string {
+	return p.note
+}
+
+func (p pluralValue) Origin() Origin {
+	return p.origin
+}
+
+// updateTag sets the tag, which selects the plural rules and formats the numbers. A new tag discards the compiled
+// messages.
 func (p pluralValue) updateTag(tag language.Tag) Value {
-	p.tag = tag
+	if p.tag != tag {
+		p.tag = tag
+		p.compiled = nil
+	}
+
 	return p
 }
 
+func (p pluralValue) compile() Value {
+	if p.compiled != nil {
+		return p
+	}
+
+	optional := func(text string) *message {
+		if len(text) == 0 {
+			return nil
+		}
+
+		return compileLocalizedMessage(text, p.tag)
+	}
+
+	p.compiled = &pluralMessages{
+		zero:  optional(p.zero),
+		one:   optional(p.one),
+		two:   optional(p.two),
+		few:   optional(p.few),
+		many:  optional(p.many),
+		other: compileLocalizedMessage(p.other, p.tag),
+	}
+
+	return p
+}
+
+// messages returns the compiled messages, which are only compiled on demand, if not imported yet
+func (p pluralValue) messages() *pluralMessages {
+	if p.compiled == nil {
+		return p.compile().(pluralValue).compiled
+	}
+
+	return p.compiled
+}
+
 func (p pluralValue) ID() string {
 	return p.Id
 }
@@ -151,45 +359,68 @@ func (p pluralValue) TextArray() ([]string, error) {
 
 // String returns other
 func (p pluralValue) Text(args ...interface{}) (string, error) {
-	return fmt.Sprintf(p.other, args...), nil
+	return p.message().String(args...), nil
 }
 
-// QuantityString returns the grammatical plural for the internal Plural implementation
+// QuantityString returns the grammatical plural for the internal Plural implementation. An ordinal plural uses
+// the quantity as the ordinal number.
 func (p pluralValue) QuantityText(quantity int, args ...interface{}) (string, error) {
 	// MatchPlural is over engineered: f and t are not used anyway and according to its test, v and w must be kept 0
 	// to just get the plural for a natural number
-	form := plural.Cardinal.MatchPlural(p.tag, quantity, 0, 0, 0, 0)
-	log.Println("quantity: ", form, p.tag)
+	msg, formatArgs := p.quantityMessage(quantity, 0, 0, 0, 0)
+
+	return quantityText(msg, formatArgs, args)
+}
+
+// QuantityTextDecimal returns the grammatical plural for the CLDR operands of the decimal, so that 1.5 or 1.0
+// may select a different plural than 1.
+func (p pluralValue) QuantityTextDecimal(quantity Decimal, args ...interface{}) (string, error) {
+	msg, formatArgs := p.quantityMessage(quantity.Operands())
+
+	return quantityText(msg, formatArgs, args)
+}
+
+// SelectText returns other
+func (p pluralValue) SelectText(selector string, args ...interface{}) (string, error) {
+	return p.Text(args...)
+}
+
+func quantityText(msg *message, formatArgs bool, args []interface{}) (string, error) {
+	if !formatArgs {
+		return msg.String(), nil
+	}
+
+	return msg.String(args...), nil
+}
+
+func (p pluralValue) message() *message {
+	return p.messages().other
+}
+
+// quantityMessage returns the message of the plural category for the CLDR operands or other, if that category is
+// empty. A category without placeholders ignores the arguments, which allows special cases like "no files" for zero.
+func (p pluralValue) quantityMessage(i, v, w, f, t int) (msg *message, formatArgs bool) {
+	form := p.rules().MatchPlural(p.tag, i, v, w, f, t)
+	msgs := p.messages()
+
 	switch form {
 	case plural.Zero:
-		return p.fallback(p.zero, args...)
+		msg = msgs.zero
 	case plural.One:
-		return p.fallback(p.one, args...)
+		msg = msgs.one
 	case plural.Two:
-		return p.fallback(p.two, args...)
+		msg = msgs.two
 	case plural.Few:
-		return p.fallback(p.few, args...)
+		msg = msgs.few
 	case plural.Many:
-		return p.fallback(p.many, args...)
-	case plural.Other:
-		fallthrough
-	default:
-		return fmt.Sprintf(p.other, args...), nil
-	}
-}
-
-// fallback uses Other if text is empty
-func (p pluralValue) fallback(text string, args ...interface{}) (string, error) {
-	if len(text) == 0 {
-		return fmt.Sprintf(p.other, args...), nil
+		msg = msgs.many
 	}
 
-	// TODO unclear if this is right, but it allows special cases without placeholders
-	if len(ParsePrintf(text)) == 0 {
-		return text, nil
+	if msg == nil {
+		return msgs.other, true
 	}
 
-	return fmt.Sprintf(text, args...), nil
+	return msg, msg.hasArgs()
 }
 
 // A simpleValue just holds a text
@@ -197,14 +428,24 @@ type simpleValue struct {
 	locale string
 	Id     string
 	String string
+	tag    language.Tag
+	note   string
+	origin Origin
+
+	// compiled is nil until compile, which happens at import time
+	compiled *message
 }
 
 // NewText returns a
 func NewText(locale string, id string, text string) Value {
+	tag := language.Make(locale)
+
 	return simpleValue{
-		locale: locale,
-		Id:     id,
-		String: text,
+		locale:   locale,
+		Id:       id,
+		String:   text,
+		tag:      tag,
+		compiled: compileLocalizedMessage(text, tag),
 	}
 }
 
@@ -216,10 +457,40 @@ func (s simpleValue) Locale() string {
 	return s.locale
 }
 
+func (s simpleValue) Note() string {
+	return s.note
+}
+
+func (s simpleValue) Origin() Origin {
+	return s.origin
+}
+
 func (s simpleValue) updateTag(tag language.Tag) Value {
+	if s.tag != tag {
+		s.tag = tag
+		s.compiled = nil
+	}
+
+	return s
+}
+
+func (s simpleValue) compile() Value {
+	if s.compiled == nil {
+		s.compiled = compileLocalizedMessage(s.String, s.tag)
+	}
+
 	return s
 }
 
+// message returns the compiled text, which is only compiled on demand, if not imported yet
+func (s simpleValue) message() *message {
+	if s.compiled == nil {
+		return compileLocalizedMessage(s.String, s.tag)
+	}
+
+	return s.compiled
+}
+
 // StringArray returns the text in a single element array
 func (s simpleValue) TextArray() ([]string, error) {
 	return []string{s.String}, nil
@@ -227,7 +498,11 @@ func (s simpleValue) TextArray() ([]string, error) {
 
 // String interpolates and returns the text
 func (s simpleValue) Text(args ...interface{}) (string, error) {
-	return fmt.Sprintf(s.String, args...), nil
+	return s.message().String(args...), nil
+}
+
+func (s simpleValue) quantityMessage(i, v, w, f, t int) (*message, bool) {
+	return s.message(), true
 }
 
 // QuantityString is equivalent to String
@@ -235,11 +510,30 @@ func (s simpleValue) QuantityText(quantity int, args ...interface{}) (string, er
 	return s.Text(args...)
 }
 
+// QuantityTextDecimal is equivalent to String
+func (s simpleValue) QuantityTextDecimal(quantity Decimal, args ...interface{}) (string, error) {
+	return s.Text(args...)
+}
+
+// SelectText is equivalent to String
+func (s simpleValue) SelectText(selector string, args ...interface{}) (string, error) {
+	return s.Text(args...)
+}
+
 // An arrayValue holds an ordered bunch of strings
 type arrayValue struct {
 	locale  string
 	Id      string
 	Strings []string
+	tag     language.Tag
+	note    string
+	origin  Origin
+
+	// list is the name of the ListStyle, if the generated accessor joins the items into a sentence
+	list string
+
+	// compiled is the first element, which is nil until compile
+	compiled *message
 }
 
 // NewTextArray creates a new translated array value
@@ -248,17 +542,56 @@ func NewTextArray(locale string, id string, items ...string) Value {
 		locale:  locale,
 		Id:      id,
 		Strings: items,
+		tag:     language.Make(locale),
 	}
 }
 
 func (a arrayValue) updateTag(tag language.Tag) Value {
+	if a.tag != tag {
+		a.tag = tag
+		a.compiled = nil
+	}
+
+	return a
+}
+
+func (a arrayValue) compile() Value {
+	if a.compiled == nil {
+		a.compiled = a.message()
+	}
+
 	return a
 }
 
+// message returns the compiled first element, which is only compiled on demand, if not imported yet
+func (a arrayValue) message() *message {
+	if a.compiled != nil {
+		return a.compiled
+	}
+
+	if len(a.Strings) > 0 {
+		return compileLocalizedMessage(a.Strings[0], a.tag)
+	}
+
+	return compileLocalizedMessage("", a.tag)
+}
+
+func (a arrayValue) quantityMessage(i, v, w, f, t int) (*message, bool) {
+	return a.message(), true
+}
+
 func (a arrayValue) Locale() string {
 	return a.locale
 }
 
+func (a arrayValue) Note() string {
+	return a.note
+}
+
+func (a arrayValue) Origin() Origin {
+	return a.origin
+}
+
 func (a arrayValue) ID() string {
 	return a.Id
 }
@@ -274,7 +607,7 @@ func (a arrayValue) TextArray() ([]string, error) {
 // Text returns the first array element or the empty string
 func (a arrayValue) Text(args ...interface{}) (string, error) {
 	if len(a.Strings) > 0 {
-		return fmt.Sprintf(a.Strings[0], args...), nil
+		return a.message().String(args...), nil
 	}
 
 	return "", nil
@@ -284,3 +617,220 @@ func (a arrayValue) Text(args ...interface{}) (string, error) {
 func (a arrayValue) QuantityText(quantity int, args ...interface{}) (string, error) {
 	return a.Text(args...)
 }
+
+// QuantityTextDecimal just returns text
+func (a arrayValue) QuantityTextDecimal(quantity Decimal, args ...interface{}) (string, error) {
+	return a.Text(args...)
+}
+
+// SelectText just returns text
+func (a arrayValue) SelectText(selector string, args ...interface{}) (string, error) {
+	return a.Text(args...)
+}
+
+// SelectBuilder creates a select value, whose cases are chosen by arbitrary selectors, like a grammatical gender.
+type SelectBuilder interface {
+	Value
+	Case(selector string, text string) SelectBuilder
+	Other(text string) SelectBuilder
+}
+
+// A selectValue is a Value, whose text is chosen by a selector instead of a quantity, like the grammatical gender in
+// "she invited you", "he invited you" or "they invited you". The other case is mandatory and used for any unknown
+// selector.
+type selectValue struct {
+	locale string
+	Id     string
+	cases  []selectCase // cases are sorted by their selector and never contain other
+	other  string
+	tag    language.Tag
+	note   string
+	origin Origin
+
+	// compiled messages are nil until compile, which happens at import time
+	compiled *selectMessages
+}
+
+// selectCase is the text for a selector
+type selectCase struct {
+	selector string
+	text     string
+}
+
+// selectMessages are the compiled texts of a select
+type selectMessages struct {
+	cases map[string]*message
+	other *message
+}
+
+// NewSelectText returns a builder for a text, which is chosen by a selector, like a grammatical gender.
+func NewSelectText(locale string, id string) SelectBuilder {
+	return selectValue{
+		locale: locale,
+		Id:     id,
+		tag:    language.Make(locale),
+	}
+}
+
+// Case sets the text for the given selector. The selector other is equivalent to Other.
+func (s selectValue) Case(selector string, text string) SelectBuilder {
+	if selector == other {
+		return s.Other(text)
+	}
+
+	// the builder is a value, so the cases must not be shared with the former copy
+	cases := make([]selectCase, 0, len(s.cases)+1)
+	for _, c := range s.cases {
+		if c.selector != selector {
+			cases = append(cases, c)
+		}
+	}
+
+	cases = append(cases, selectCase{selector: selector, text: text})
+	sort.Slice(cases, func(i, j int) bool {
+		return cases[i].selector < cases[j].selector
+	})
+
+	s.cases = cases
+	s.compiled = nil
+
+	return s
+}
+
+func (s selectValue) Other(text string) SelectBuilder {
+	s.other = text
+	s.compiled = nil
+
+	return s
+}
+
+// selectors returns the sorted selectors of all cases, excluding other
+func (s selectValue) selectors() []string {
+	res := make([]string, 0, len(s.cases))
+	for _, c := range s.cases {
+		res = append(res, c.selector)
+	}
+
+	return res
+}
+
+// has returns true, if there is a case for the selector
+func (s selectValue) has(selector string) bool {
+	for _, c := range s.cases {
+		if c.selector == selector {
+			return true
+		}
+	}
+
+	return false
+}
+
+// get returns the text of the given selector or other
+func (s selectValue) get(selector string) string {
+	for _, c := range s.cases {
+		if c.selector == selector {
+			return c.text
+		}
+	}
+
+	return s.other
+}
+
+func (s selectValue) ID() string {
+	return s.Id
+}
+
+func (s selectValue) Locale() string {
+	return s.locale
+}
+
+func (s selectValue) Note() string {
+	return s.note
+}
+
+func (s selectValue) Origin() Origin {
+	return s.origin
+}
+
+func (s selectValue) updateTag(tag language.Tag) Value {
+	if s.tag != tag {
+		s.tag = tag
+		s.compiled = nil
+	}
+
+	return s
+}
+
+func (s selectValue) compile() Value {
+	if s.compiled != nil {
+		return s
+	}
+
+	s.compiled = &selectMessages{
+		cases: make(map[string]*message, len(s.cases)),
+		other: compileLocalizedMessage(s.other, s.tag),
+	}
+
+	for _, c := range s.cases {
+		s.compiled.cases[c.selector] = compileLocalizedMessage(c.text, s.tag)
+	}
+
+	return s
+}
+
+// messages returns the compiled messages, which are only compiled on demand, if not imported yet
+func (s selectValue) messages() *selectMessages {
+	if s.compiled == nil {
+		return s.compile().(selectValue).compiled
+	}
+
+	return s.compiled
+}
+
+func (s selectValue) message() *message {
+	return s.messages().other
+}
+
+func (s selectValue) quantityMessage(i, v, w, f, t int) (*message, bool) {
+	return s.message(), true
+}
+
+// selectMessage returns the message of the selector or other, if there is no such case. Like a plural category,
+// a case without placeholders ignores the arguments.
+func (s selectValue) selectMessage(selector string) (msg *message, formatArgs bool) {
+	msgs := s.messages()
+
+	msg = msgs.cases[selector]
+	if msg == nil {
+		return msgs.other, true
+	}
+
+	return msg, msg.hasArgs()
+}
+
+// TextArray returns the other case within a one element array
+func (s selectValue) TextArray() ([]string, error) {
+	return []string{s.other}, nil
+}
+
+// Text returns the other case
+func (s selectValue) Text(args ...interface{}) (string, error) {
+	return s.message().String(args...), nil
+}
+
+// QuantityText returns the other case
+func (s selectValue) QuantityText(quantity int, args ...interface{}) (string, error) {
+	return s.Text(args...)
+}
+
+// QuantityTextDecimal returns the other case
+func (s selectValue) QuantityTextDecimal(quantity Decimal, args ...interface{}) (string, error) {
+	return s.Text(args...)
+}
+
+// SelectText returns the case of the selector or other, if there is no such case
+func (s selectValue) SelectText(selector string, args ...interface{}) (string, error) {
+	msg, formatArgs := s.selectMessage(selector)
+
+	return quantityText(msg, formatArgs, args)
+}
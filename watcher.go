@@ -0,0 +1,378 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+// nolint: goimports // the linter is broken
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultWatchInterval is the poll interval, if none has been configured
+const defaultWatchInterval = 2 * time.Second
+
+// WatchOptions configures a Watcher
+type WatchOptions struct {
+	// Interval between two polls. Defaults to 2 seconds.
+	Interval time.Duration
+	// Pattern selects the files of watched directories, like "strings*.xml". Defaults to all files.
+	Pattern string
+	// OnError is invoked for each failed reload. The previous values stay live.
+	OnError func(fname string, err error)
+	// OnReload is invoked for each successful reload and for each deleted file, whose keys have been removed.
+	OnReload func(fname string)
+}
+
+// A Watcher polls files for changes and reloads them into a bundle. A changed file is parsed again, validated
+// against all other locales and only if that passes, the values of its locale are swapped at once. The keys
+// of a file replace those which it contained before, so a locale may be composed of multiple files. The keys of a
+// deleted file are removed, if the remaining values are still valid.
+type Watcher struct {
+	bundle   *Bundle
+	importer Importer
+	opts     WatchOptions
+	paths    []string
+
+	mutex sync.Mutex
+	files map[string]watchedFile
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// watchedFile is the last known state of a file
+type watchedFile struct {
+	modTime time.Time
+	size    int64
+	keys    []string
+	deleted bool // deleted is true, if the file has been deleted but its keys could not be removed
+}
+
+// Watch starts polling the given files and directories. The files are expected to be imported already, e.g. by
+// ImportFile, so that only later modifications are reloaded. New files in a watched directory are imported.
+// The locale is detected from the file name. Close the returned watcher to stop polling.
+func (b *Bundle) Watch(importer Importer, opts WatchOptions, paths ...string) (*Watcher, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultWatchInterval
+	}
+
+	if opts.Pattern == "" {
+		opts.Pattern = "*"
+	}
+
+	if _, err := filepath.Match(opts.Pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %w", opts.Pattern, err)
+	}
+
+	w := &Watcher{
+		bundle:   b,
+		importer: importer,
+		opts:     opts,
+		paths:    paths,
+		files:    make(map[string]watchedFile),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	var listErr error
+
+	fnames := w.list(func(path string, err error) {
+		if listErr == nil {
+			listErr = err
+		}
+	})
+
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	// remember the current state and which keys each file contributes, without touching the bundle
+	for _, fname := range fnames {
+		info, err := os.Stat(fname)
+		if err != nil {
+			return nil, fmt.Errorf("cannot watch '%s': %w", fname, err)
+		}
+
+		res, err := w.parse(fname)
+		if err != nil {
+			return nil, fmt.Errorf("cannot watch '%s': %w", fname, err)
+		}
+
+		w.files[fname] = watchedFile{modTime: info.ModTime(), size: info.Size(), keys: res.Keys()}
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+// Close stops polling and waits until a running poll has been finished.
+func (w *Watcher) Close() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+
+	<-w.done
+}
+
+func (w *Watcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.Poll()
+		}
+	}
+}
+
+// Poll checks all watched files at once, reloads the modified ones and removes the keys of the deleted ones. A path,
+// which cannot be checked, is reported and skipped.
+func (w *Watcher) Poll() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// a missing path is not reported, because its files are just deleted
+	fnames := w.list(func(path string, err error) {
+		if !errors.Is(err, fs.ErrNotExist) {
+			w.failed(path, err)
+		}
+	})
+
+	listed := make(map[string]bool)
+	for _, fname := range fnames {
+		listed[fname] = true
+	}
+
+	for _, fname := range sortedFiles(w.files) {
+		if !listed[fname] {
+			w.remove(fname)
+		}
+	}
+
+	for _, fname := range fnames {
+		info, err := os.Stat(fname)
+		if err != nil {
+			w.failed(fname, err)
+			continue
+		}
+
+		last, known := w.files[fname]
+		if known && last.modTime.Equal(info.ModTime()) && last.size == info.Size() {
+			continue
+		}
+
+		keys, err := w.reload(fname, last.keys)
+		if err != nil {
+			// remember the state anyway, so that a broken file is not reported over and over again
+			w.files[fname] = watchedFile{modTime: info.ModTime(), size: info.Size(), keys: last.keys}
+			w.failed(fname, err)
+
+			continue
+		}
+
+		w.files[fname] = watchedFile{modTime: info.ModTime(), size: info.Size(), keys: keys}
+
+		if w.opts.OnReload != nil {
+			w.opts.OnReload(fname)
+		}
+	}
+}
+
+// remove unloads the keys of a deleted file. A file, which is still there, like in a temporarily unreadable
+// directory, is kept.
+func (w *Watcher) remove(fname string) {
+	last := w.files[fname]
+
+	if _, err := os.Stat(fname); !errors.Is(err, fs.ErrNotExist) {
+		if err != nil {
+			w.failed(fname, err)
+		}
+
+		return
+	}
+
+	tag := w.bundle.res.Locale(guessLocaleFromFilename(fname))
+	if target := w.bundle.res.Get(tag.String()); target != nil {
+		if err := w.swap(target, last.keys, newResources(tag)); err != nil {
+			// keep trying, because the other locales may be deleted later, but report it only once
+			if !last.deleted {
+				last.deleted = true
+				w.files[fname] = last
+				w.failed(fname, err)
+			}
+
+			return
+		}
+	}
+
+	delete(w.files, fname)
+
+	if w.opts.OnReload != nil {
+		w.opts.OnReload(fname)
+	}
+}
+
+// reload parses and validates the file and swaps the values of its locale. Returns the new keys of the file.
+func (w *Watcher) reload(fname string, oldKeys []string) ([]string, error) {
+	parsed, err := w.parse(fname)
+	if err != nil {
+		return nil, err
+	}
+
+	// a new locale is only configured after a successful validation
+	target := w.bundle.res.Get(parsed.tag.String())
+	if target == nil {
+		if err := validate(append(w.bundle.res.Resources(), parsed)); err != nil {
+			return nil, fmt.Errorf("invalid reload: %w", err)
+		}
+
+		target = w.bundle.res.Configure(parsed.tag.String())
+	}
+
+	if err := w.swap(target, oldKeys, parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed.Keys(), nil
+}
+
+// swap replaces the old keys of the target by the parsed values, if all locales are still valid
+func (w *Watcher) swap(target *Resources, oldKeys []string, parsed *Resources) error {
+	// hold the lock, so that no other modification gets lost. A failed reload just republishes the current values.
+	target.lock()
+	defer target.unlock()
+
+	// the candidate is the current locale without the old keys of the file but with its new values
+	candidate := newResources(target.tag)
+	candidate.lock()
+	for key, value := range target.values {
+		candidate.values[key] = value
+	}
+
+	for _, key := range oldKeys {
+		delete(candidate.values, key)
+	}
+
+	for key, value := range parsed.load() {
+		candidate.values[key] = value
+	}
+	candidate.unlock()
+
+	var all []*Resources
+
+	for _, res := range w.bundle.res.Resources() {
+		if res == target {
+			res = candidate
+		}
+
+		// a locale without any value, e.g. after deleting its only file, just uses the fallbacks
+		if len(res.load()) > 0 {
+			all = append(all, res)
+		}
+	}
+
+	if err := validate(all); err != nil {
+		return fmt.Errorf("invalid reload: %w", err)
+	}
+
+	target.values = candidate.load()
+
+	return nil
+}
+
+// parse imports the file into new detached resources
+func (w *Watcher) parse(fname string) (*Resources, error) {
+	file, err := os.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	res := newResources(w.bundle.res.Locale(guessLocaleFromFilename(fname)))
+	if err := w.importer.Import(res, file); err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	return res, nil
+}
+
+// list returns the watched files and the matching files of the watched directories in a stable order. A path,
+// which cannot be listed, is passed to onError and skipped.
+func (w *Watcher) list(onError func(path string, err error)) []string {
+	var res []string
+
+	for _, path := range w.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			onError(path, fmt.Errorf("cannot watch '%s': %w", path, err))
+			continue
+		}
+
+		if !info.IsDir() {
+			res = append(res, path)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(path, w.opts.Pattern))
+		if err != nil {
+			onError(path, fmt.Errorf("cannot watch '%s': %w", path, err))
+			continue
+		}
+
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() {
+				res = append(res, match)
+			}
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// sortedFiles returns the names of the watched files in a stable order
+func sortedFiles(files map[string]watchedFile) []string {
+	res := make([]string, 0, len(files))
+	for fname := range files {
+		res = append(res, fname)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func (w *Watcher) failed(fname string, err error) {
+	if w.opts.OnError != nil {
+		w.opts.OnError(fname, err)
+	}
+}
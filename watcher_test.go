@@ -0,0 +1,199 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package i18n
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestFile(t *testing.T, fname string, content string, modTime time.Time) {
+	if err := ioutil.WriteFile(fname, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(fname, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	und := filepath.Join(dir, "strings.xml")
+	de := filepath.Join(dir, "strings-de.xml")
+	now := time.Now()
+
+	writeTestFile(t, und, `<resources><string name="a">A</string><string name="b">B %s</string></resources>`, now)
+	writeTestFile(t, de, `<resources><string name="a">A1</string><string name="b">B1 %s</string></resources>`, now)
+
+	bundle := NewBundle()
+	for _, fname := range []string{und, de} {
+		if err := bundle.ImportFile(AndroidImporter{}, fname); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var errs, reloads []string
+
+	watcher, err := bundle.Watch(AndroidImporter{}, WatchOptions{
+		Interval: time.Hour,
+		Pattern:  "strings*.xml",
+		OnError: func(fname string, err error) {
+			errs = append(errs, fname)
+		},
+		OnReload: func(fname string) {
+			reloads = append(reloads, fname)
+		},
+	}, dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer watcher.Close()
+
+	text := func(id string, args ...interface{}) string {
+		str, err := bundle.From("de").Text(id, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return str
+	}
+
+	// nothing has been changed yet
+	watcher.Poll()
+
+	if len(errs) != 0 || len(reloads) != 0 {
+		t.Fatalf("unexpected reload %v %v", errs, reloads)
+	}
+
+	// a valid change is swapped in
+	writeTestFile(t, de, `<resources><string name="a">A2</string><string name="b">B2 %s</string></resources>`,
+		now.Add(time.Second))
+	watcher.Poll()
+
+	if len(reloads) != 1 || text("a") != "A2" || text("b", "x") != "B2 x" {
+		t.Fatalf("expected a reload but got %v %v", errs, reloads)
+	}
+
+	// an invalid change is reported and the old values stay live
+	writeTestFile(t, de, `<resources><string name="a">A3</string><string name="b">B3</string></resources>`,
+		now.Add(2*time.Second))
+	watcher.Poll()
+
+	if len(errs) != 1 || errs[0] != de || text("a") != "A2" {
+		t.Fatalf("expected a failed reload but got %v %v", errs, reloads)
+	}
+
+	// a broken file is reported only once
+	watcher.Poll()
+
+	if len(errs) != 1 {
+		t.Fatalf("expected a single failure but got %v", errs)
+	}
+
+	// a new file of a new locale is imported
+	writeTestFile(t, filepath.Join(dir, "strings-fr.xml"),
+		`<resources><string name="a">A4</string><string name="b">B4 %s</string></resources>`, now)
+	watcher.Poll()
+
+	if str, _ := bundle.From("fr").Text("a"); str != "A4" {
+		t.Fatalf("expected A4 but got '%s'", str)
+	}
+}
+
+func TestWatcherDelete(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "sub")
+	und := filepath.Join(dir, "strings.xml")
+	de := filepath.Join(dir, "strings-de.xml")
+	subDe := filepath.Join(sub, "strings-de.xml")
+	fr := filepath.Join(dir, "strings-fr.xml")
+	now := time.Now()
+
+	if err := os.Mkdir(sub, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFile(t, und, `<resources><string name="a">A</string><string name="c">C</string></resources>`, now)
+	writeTestFile(t, de, `<resources><string name="a">A1</string></resources>`, now)
+	writeTestFile(t, subDe, `<resources><string name="c">C1</string></resources>`, now)
+	writeTestFile(t, fr, `<resources><string name="a">A2</string><string name="c">C2</string></resources>`, now)
+
+	bundle := NewBundle()
+	for _, fname := range []string{und, de, subDe, fr} {
+		if err := bundle.ImportFile(AndroidImporter{}, fname); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var errs, reloads []string
+
+	watcher, err := bundle.Watch(AndroidImporter{}, WatchOptions{
+		Interval: time.Hour,
+		Pattern:  "strings*.xml",
+		OnError: func(fname string, err error) {
+			errs = append(errs, fname)
+		},
+		OnReload: func(fname string) {
+			reloads = append(reloads, fname)
+		},
+	}, dir, sub)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer watcher.Close()
+
+	// the keys of a deleted locale are removed
+	if err := os.Remove(fr); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher.Poll()
+
+	if len(errs) != 0 || len(reloads) != 1 || reloads[0] != fr || bundle.From("fr").Value("a") != nil {
+		t.Fatalf("expected the removal of fr but got %v %v", errs, reloads)
+	}
+
+	// removing the keys of a part of a locale must still be valid, otherwise they stay and it is reported once
+	if err := os.Remove(subDe); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher.Poll()
+	watcher.Poll()
+
+	if str, _ := bundle.From("de").Text("c"); len(errs) != 1 || errs[0] != subDe || str != "C1" {
+		t.Fatalf("expected a failed removal but got %v %v '%s'", errs, reloads, str)
+	}
+
+	// a missing path does not stop the poll of the other ones
+	if err := os.Remove(sub); err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFile(t, de, `<resources><string name="a">A3</string></resources>`, now.Add(time.Second))
+	watcher.Poll()
+
+	if str, _ := bundle.From("de").Text("a"); len(errs) != 1 || str != "A3" {
+		t.Fatalf("expected a reload but got %v %v '%s'", errs, reloads, str)
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xliff
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/golangee/i18n/android"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// goVerbs are the verbs which we take over from a native placeholder code, anything else becomes %v.
+const goVerbs = "bcdefosxX"
+
+// Decode converts the inner xml of a source or target into a go printf string. Any text is escaped, so that a literal
+// % becomes %%. The inline placeholders <ph/> and <x/> are replaced by %[n]v, where n numbers the placeholders by the
+// first appearance of their id, because XLIFF ids are arbitrary and no argument indices. Only a native printf code
+// with an explicit index, like <ph id="1">%2$s</ph>, declares the argument index. If the placeholder carries a native
+// printf code, like <ph id="1">%s</ph> or <ph id="1" disp="%d"/>, its verb is used instead of v.
+// Paired codes like <g>, <pc> or <mrk> are dropped but their content is kept. Isolated codes like <bx>, <ex>,
+// <sc>, <ec> or <it> are dropped entirely.
+func Decode(innerXML string) (string, error) {
+	return DecodeTarget(innerXML, innerXML)
+}
+
+// DecodeTarget converts the inner xml of a target just like Decode, but the placeholders are numbered by their
+// appearance in the source, so that a translation may reorder them. Ids, which are not contained in the source, get
+// the next free index.
+func DecodeTarget(source, target string) (string, error) {
+	table, err := newPlaceholderTable(source)
+	if err != nil {
+		return "", err
+	}
+
+	sb := &strings.Builder{}
+	err = walk(target, func(text string) {
+		sb.WriteString(strings.ReplaceAll(text, "%", "%%"))
+	}, func(id, native string) {
+		sb.WriteString("%[" + strconv.Itoa(table.index(id)) + "]" + string(verbOf(native)))
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// placeholderTable assigns the argument indices to the placeholder ids
+type placeholderTable struct {
+	indices map[string]int
+	used    map[int]bool
+}
+
+// newPlaceholderTable reserves the explicit indices of the native codes first and numbers the other placeholders
+// of the source by their first appearance.
+func newPlaceholderTable(source string) (placeholderTable, error) {
+	table := placeholderTable{indices: make(map[string]int), used: make(map[int]bool)}
+
+	var ids []string
+
+	err := walk(source, func(string) {}, func(id, native string) {
+		ids = append(ids, id)
+
+		specs := android.ParsePrintf(native)
+		if len(specs) == 0 || !specs[0].Indexed() {
+			return
+		}
+
+		if _, ok := table.indices[id]; !ok && !table.used[specs[0].Arg+1] {
+			table.indices[id] = specs[0].Arg + 1
+			table.used[specs[0].Arg+1] = true
+		}
+	})
+
+	for _, id := range ids {
+		table.index(id)
+	}
+
+	return table, err
+}
+
+// index returns the index of the id or assigns the next free index to it
+func (t placeholderTable) index(id string) int {
+	if index, ok := t.indices[id]; ok {
+		return index
+	}
+
+	index := 1
+	for t.used[index] {
+		index++
+	}
+
+	t.indices[id] = index
+	t.used[index] = true
+
+	return index
+}
+
+// walk calls text for any character data and placeholder for each <ph/> or <x/>. A placeholder without an id gets
+// the id #n, where n counts the placeholders without an id, so that they are matched by their order.
+func walk(innerXML string, text func(string), placeholder func(id, native string)) error {
+	dec := xml.NewDecoder(strings.NewReader("<inline>" + innerXML + "</inline>"))
+	anonymous := 0
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid inline content: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			text(string(t))
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "ph", "x":
+				native, err := readText(dec)
+				if err != nil {
+					return err
+				}
+
+				if native == "" {
+					native = attr(t, "disp", "equiv", "equiv-text")
+				}
+
+				id := attr(t, "id")
+				if id == "" {
+					anonymous++
+					id = "#" + strconv.Itoa(anonymous)
+				}
+
+				placeholder(id, native)
+			case "bx", "ex", "sc", "ec", "it":
+				if err := dec.Skip(); err != nil {
+					return fmt.Errorf("invalid inline content: %w", err)
+				}
+			}
+		}
+	}
+}
+
+// readText consumes all tokens until the end of the current element and returns the contained character data.
+func readText(dec *xml.Decoder) (string, error) {
+	sb := &strings.Builder{}
+	depth := 1
+
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("invalid inline content: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			sb.Write(t)
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// attr returns the value of the first defined attribute of the given names.
+func attr(elem xml.StartElement, names ...string) string {
+	for _, name := range names {
+		for _, a := range elem.Attr {
+			if a.Name.Local == name {
+				return a.Value
+			}
+		}
+	}
+
+	return ""
+}
+
+// verbOf parses the native code as an android or java printf directive and returns its verb or v.
+func verbOf(native string) byte {
+	specs := android.ParsePrintf(native)
+	if len(specs) == 0 {
+		return 'v'
+	}
+
+	verb := specs[0].Verb()
+	if strings.IndexByte(goVerbs, verb) < 0 {
+		return 'v'
+	}
+
+	return verb
+}
@@ -0,0 +1,194 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xliff
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Document is the root element of an XLIFF 1.2 or 2.0 file. Both versions are mapped onto the same model, so
+// that the fields of the other version are just empty.
+type Document struct {
+	XMLName xml.Name `xml:"xliff"`
+	Version string   `xml:"version,attr"`
+	SrcLang string   `xml:"srcLang,attr"` // SrcLang is only defined by XLIFF 2.0
+	TrgLang string   `xml:"trgLang,attr"` // TrgLang is only defined by XLIFF 2.0
+	Files   []File   `xml:"file"`
+}
+
+// File is a single translation file. XLIFF 1.2 puts its units into a body element, XLIFF 2.0 directly into the file.
+type File struct {
+	XMLName        xml.Name `xml:"file"`
+	Original       string   `xml:"original,attr"`
+	SourceLanguage string   `xml:"source-language,attr"` // SourceLanguage is only defined by XLIFF 1.2
+	TargetLanguage string   `xml:"target-language,attr"` // TargetLanguage is only defined by XLIFF 1.2
+	Body           Group    `xml:"body"`
+	Group
+}
+
+// Group collects units and other groups. The ResType (1.2) or Type (2.0) attribute declares if a group is a plural or
+// an array.
+type Group struct {
+	ID         string      `xml:"id,attr"`
+	Name       string      `xml:"name,attr"`    // Name is only defined by XLIFF 2.0
+	ResName    string      `xml:"resname,attr"` // ResName is only defined by XLIFF 1.2
+	ResType    string      `xml:"restype,attr"` // ResType is only defined by XLIFF 1.2
+	Type       string      `xml:"type,attr"`    // Type is only defined by XLIFF 2.0
+	TransUnits []TransUnit `xml:"trans-unit"`
+	Units      []Unit      `xml:"unit"`
+	Groups     []Group     `xml:"group"`
+}
+
+// Key returns the resname or name, if defined, otherwise the id.
+func (g Group) Key() string {
+	return key(g.ID, g.ResName, g.Name)
+}
+
+// IsPlural returns true, if the group declares a plural, like restype="x-gettext-plurals" or type="x:plurals".
+func (g Group) IsPlural() bool {
+	return strings.Contains(strings.ToLower(g.ResType+g.Type), "plural")
+}
+
+// IsArray returns true, if the group declares an ordered list, like restype="x-android-string-array" or
+// type="x:array".
+func (g Group) IsArray() bool {
+	return strings.Contains(strings.ToLower(g.ResType+g.Type), "array")
+}
+
+// Segments returns the units of this group in document order, regardless of the XLIFF version.
+func (g Group) Segments() []Segment {
+	res := make([]Segment, 0, len(g.TransUnits)+len(g.Units))
+	for _, tu := range g.TransUnits {
+		res = append(res, Segment{
+			ID:     tu.Key(),
+			Source: tu.Source,
+			Target: tu.Target,
+		})
+	}
+
+	for _, unit := range g.Units {
+		seg := Segment{ID: unit.Key()}
+		// an untranslated segment falls back to its own source and not to the entire source
+		for _, s := range unit.Segments {
+			seg.Source.InnerXML += s.Source.InnerXML
+			if strings.TrimSpace(s.Target.InnerXML) != "" {
+				seg.Target.InnerXML += s.Target.InnerXML
+			} else {
+				seg.Target.InnerXML += s.Source.InnerXML
+			}
+		}
+
+		res = append(res, seg)
+	}
+
+	return res
+}
+
+// TransUnit is the XLIFF 1.2 translation unit
+type TransUnit struct {
+	XMLName xml.Name `xml:"trans-unit"`
+	ID      string   `xml:"id,attr"`
+	ResName string   `xml:"resname,attr"`
+	Source  Inline   `xml:"source"`
+	Target  Inline   `xml:"target"`
+}
+
+// Key returns the resname, if defined, otherwise the id.
+func (t TransUnit) Key() string {
+	return key(t.ID, t.ResName, "")
+}
+
+// Unit is the XLIFF 2.0 translation unit, which consists of at least one segment.
+type Unit struct {
+	XMLName  xml.Name  `xml:"unit"`
+	ID       string    `xml:"id,attr"`
+	Name     string    `xml:"name,attr"`
+	Segments []Segment `xml:"segment"`
+}
+
+// Key returns the name, if defined, otherwise the id.
+func (u Unit) Key() string {
+	return key(u.ID, "", u.Name)
+}
+
+// Segment is a source and target pair of inline content.
+type Segment struct {
+	ID     string `xml:"id,attr"`
+	Source Inline `xml:"source"`
+	Target Inline `xml:"target"`
+}
+
+// Text returns the decoded target or, if empty, the decoded source. The placeholders of both are numbered by
+// their appearance in the source.
+func (s Segment) Text() (string, error) {
+	if strings.TrimSpace(s.Target.InnerXML) != "" {
+		return DecodeTarget(s.Source.InnerXML, s.Target.InnerXML)
+	}
+
+	return Decode(s.Source.InnerXML)
+}
+
+// Inline contains the raw content of a source or target, which may contain inline elements like ph or x.
+type Inline struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+func key(id, resname, name string) string {
+	if resname != "" {
+		return resname
+	}
+
+	if name != "" {
+		return name
+	}
+
+	return id
+}
+
+// Read parses an XLIFF 1.2 or 2.0 document
+func Read(reader io.Reader) (Document, error) {
+	res := Document{}
+	tmp, err := ioutil.ReadAll(reader)
+
+	if err != nil {
+		return res, fmt.Errorf("failed to read entire xml: %w", err)
+	}
+
+	err = xml.Unmarshal(tmp, &res)
+	if err != nil {
+		return res, fmt.Errorf("failed to parse xml: %w", err)
+	}
+
+	return res, nil
+}
+
+// ReadFile parses an XLIFF file from the file system
+func ReadFile(fname string) (Document, error) {
+	file, err := os.Open(fname)
+	if err != nil {
+		return Document{}, fmt.Errorf("cannot open '%s':%w", fname, err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return Read(file)
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Torben Schinke
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xliff
+
+import (
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	doc, err := ReadFile("strings_test.xlf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.Version != "1.2" || len(doc.Files) != 1 {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+
+	body := doc.Files[0].Body
+	if len(body.TransUnits) != 5 || len(body.Groups) != 2 {
+		t.Fatalf("unexpected body %+v", body)
+	}
+
+	if !body.Groups[0].IsPlural() || !body.Groups[1].IsArray() {
+		t.Fatalf("unexpected groups %+v", body.Groups)
+	}
+
+	doc, err = ReadFile("strings_test.xliff")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := doc.Files[0]
+	if doc.Version != "2.0" || len(file.Units) != 2 || len(file.Groups) != 2 {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+
+	segs := file.Segments()
+	if segs[1].ID != "hello_x" {
+		t.Fatalf("expected name as key but got %s", segs[1].ID)
+	}
+
+	text, err := segs[1].Text()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text != "Hallo %[1]s!" {
+		t.Fatalf("unexpected text '%s'", text)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+		want string
+	}{
+		{"plain text", `hello world`, `hello world`},
+		{"escape percent", `100% done`, `100%% done`},
+		{"entities", `&lt;b&gt; &amp; &quot;`, `<b> & "`},
+		{"ph without code", `hello <ph id="1"/>`, `hello %[1]v`},
+		{"ph with code", `<ph id="2">%2$d</ph> cats of <ph id="1">%1$s</ph>`, `%[2]d cats of %[1]s`},
+		{"arbitrary ids", `<ph id="3"/> and <ph id="7"/>`, `%[1]v and %[2]v`},
+		{"mixed ids", `<ph id="2"/>, <ph id="a"/>, <ph id="1"/> and <ph id="2"/>`, `%[1]v, %[2]v, %[3]v and %[1]v`},
+		{"without ids", `<ph/> and <ph/>`, `%[1]v and %[2]v`},
+		{"explicit index", `<ph id="a"/> and <ph id="b">%1$s</ph>`, `%[2]v and %[1]s`},
+		{"x with equiv-text", `<x id="1" equiv-text="%s"/>`, `%[1]s`},
+		{"ph with disp", `<ph id="ph1" disp="%d"/> and <ph id="ph2"/>`, `%[1]d and %[2]v`},
+		{"unsupported verb", `<ph id="1">%1$u</ph>`, `%[1]v`},
+		{"paired codes", `<g id="1">bold <mrk mtype="x">text</mrk></g>`, `bold text`},
+		{"isolated codes", `a<bx id="1"/>b<ex id="1"/>c<sc id="2"/>d<ec startRef="2"/>`, `abcd`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		target string
+		want   string
+	}{
+		{"same order", `<ph id="3"/> of <ph id="7"/>`, `<ph id="3"/> von <ph id="7"/>`, `%[1]v von %[2]v`},
+		{"reordered", `<ph id="3">%d</ph> cats of <ph id="7">%s</ph>`, `<ph id="7">%s</ph> hat <ph id="3">%d</ph> Katzen`,
+			`%[2]s hat %[1]d Katzen`},
+		{"unknown id", `<ph id="a"/>`, `<ph id="b"/> <ph id="a"/>`, `%[2]v %[1]v`},
+		{"without ids", `<ph/> of <ph/>`, `<ph/> von <ph/>`, `%[1]v von %[2]v`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeTarget(tt.source, tt.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("DecodeTarget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}